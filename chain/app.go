@@ -152,6 +152,10 @@ import (
 	ibchooks "github.com/cosmos/ibc-apps/modules/ibc-hooks/v8"
 	ibchookskeeper "github.com/cosmos/ibc-apps/modules/ibc-hooks/v8/keeper"
 	ibchookstypes "github.com/cosmos/ibc-apps/modules/ibc-hooks/v8/types"
+
+	gmpmiddleware "axelar-cosmos-go/cosmos-network-integration/gmp_middleware"
+	gmppayload "axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
+	sendreceive "axelar-cosmos-go/cosmos-network-integration/send-receive/cosmos"
 	// this line is used by starport scaffolding # stargate/app/moduleImport
 )
 
@@ -185,6 +189,7 @@ var maccPerms = map[string][]string{
 	ibcfeetypes.ModuleName:      nil,
 	icatypes.ModuleName:         nil,
 	wasmtypes.ModuleName:        {authtypes.Burner},
+	gmpmiddleware.ModuleName:    nil,
 
 	// this line is used by starport scaffolding # stargate/app/maccPerms
 }
@@ -224,6 +229,7 @@ var (
 		packetforward.AppModuleBasic{},
 		wasm.AppModuleBasic{},
 		ibchooks.AppModuleBasic{},
+		gmpmiddleware.AppModuleBasic{},
 	)
 )
 
@@ -271,6 +277,7 @@ type SmaplechainApp struct {
 	WasmClientKeeper    wasmlckeeper.Keeper
 	RatelimitKeeper     ratelimitkeeper.Keeper
 	IBCHooksKeeper      ibchookskeeper.Keeper
+	GMPKeeper           *gmpmiddleware.Keeper
 
 	// Middleware for IBCHooks
 	Ics20WasmHooks   *ibchooks.WasmHooks
@@ -396,6 +403,7 @@ func NewSmaplechainApp(
 		wasmlctypes.StoreKey,
 		ratelimittypes.StoreKey,
 		ibchookstypes.StoreKey,
+		gmpmiddleware.StoreKey,
 	)
 
 	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
@@ -693,6 +701,25 @@ func NewSmaplechainApp(
 	)
 	app.PacketForwardKeeper.SetTransferKeeper(app.TransferKeeper)
 
+	// Create the GMP keeper and register the handlers for inbound Axelar general messages.
+	// Handlers are routed by destination address and/or payload version prefix.
+	app.GMPKeeper = gmpmiddleware.NewKeeper(
+		appCodec,
+		runtime.NewKVStoreService(keys[gmpmiddleware.StoreKey]),
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+		app.IBCKeeper.ChannelKeeper,
+		app.TransferKeeper,
+		app.BankKeeper,
+		gmpmiddleware.CircuitPermissionsKeeper{Permissions: app.CircuitKeeper.Permissions},
+	)
+	gmpRouter := gmpmiddleware.NewRouter()
+	gmpRouter.AddRoute("", gmppayload.VersionABI.Bytes(), sendreceive.NewSendHandler(app.BankKeeper))
+	gmpRouter.AddRoute("", gmppayload.VersionCosmWasmABI.Bytes(), gmpmiddleware.NewWasmHandler(
+		wasmkeeper.NewDefaultPermissionKeeper(&app.WasmKeeper), // wasm keeper initialized below
+		app.BankKeeper,
+	))
+	app.GMPKeeper.SetRouter(gmpRouter)
+
 	app.ICAHostKeeper = icahostkeeper.NewKeeper(
 		appCodec,
 		keys[icahosttypes.StoreKey],
@@ -745,6 +772,8 @@ func NewSmaplechainApp(
 		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 		wasmOpts...,
 	)
+	// GMP deliveries that chain into a contract execute it through the wasm keeper.
+	app.GMPKeeper.SetContractKeeper(wasmkeeper.NewDefaultPermissionKeeper(&app.WasmKeeper))
 
 	wasmLightClientQuerier := wasmlctypes.QueryPlugins{
 		// Custom: MyCustomQueryPlugin(),
@@ -778,8 +807,15 @@ func NewSmaplechainApp(
 	)
 
 	// Create Transfer Stack
+	// RecvPacket, message that originates from core IBC and goes down to app, the flow is:
+	// channel.RecvPacket -> memorouter -> packetforward -> fee -> ratelimit -> ibchooks -> gmp -> transfer
+	// The gmp middleware sits directly above transfer so that Axelar packets have already passed
+	// the rate limits, and it only runs its handlers once transfer has credited the tokens.
+	// The gmp memo router on top rejects memos that more than one of packetforward, ibchooks and
+	// gmp would act on, before any of them sees the packet.
 	var transferStack porttypes.IBCModule
 	transferStack = transfer.NewIBCModule(app.TransferKeeper)
+	transferStack = gmpmiddleware.NewIBCMiddleware(transferStack, app.GMPKeeper)
 	transferStack = ibchooks.NewIBCMiddleware(transferStack, &app.HooksICS4Wrapper)
 	transferStack = ibcratelimitmodule.NewIBCMiddleware(app.RatelimitKeeper, transferStack)
 	transferStack = ibcfee.NewIBCMiddleware(transferStack, app.IBCFeeKeeper)
@@ -790,6 +826,7 @@ func NewSmaplechainApp(
 		packetforwardkeeper.DefaultForwardTransferPacketTimeoutTimestamp,
 		packetforwardkeeper.DefaultRefundTransferPacketTimeoutTimestamp,
 	)
+	transferStack = gmpmiddleware.NewMemoRouter(transferStack, app.GMPKeeper)
 
 	// Create Interchain Accounts Stack
 	// SendPacket, since it is originating from the application to core IBC:
@@ -873,6 +910,7 @@ func NewSmaplechainApp(
 		packetforward.NewAppModule(app.PacketForwardKeeper, app.GetSubspace(packetforwardtypes.ModuleName)),
 		wasmlc.NewAppModule(app.WasmClientKeeper),
 		ibcratelimitmodule.NewAppModule(appCodec, app.RatelimitKeeper),
+		gmpmiddleware.NewAppModule(app.GMPKeeper),
 	)
 
 	// BasicModuleManager defines the module BasicManager is in charge of setting up basic,
@@ -945,6 +983,7 @@ func NewSmaplechainApp(
 		wasmlctypes.ModuleName,
 		ratelimittypes.ModuleName,
 		ibchookstypes.ModuleName,
+		gmpmiddleware.ModuleName,
 
 		// this line is used by starport scaffolding # stargate/app/endBlockers
 	)
@@ -988,6 +1027,7 @@ func NewSmaplechainApp(
 		wasmlctypes.ModuleName,
 		ratelimittypes.ModuleName,
 		ibchookstypes.ModuleName,
+		gmpmiddleware.ModuleName,
 		// this line is used by starport scaffolding # stargate/app/initGenesis
 	}
 	app.ModuleManager.SetOrderInitGenesis(genesisModuleOrder...)
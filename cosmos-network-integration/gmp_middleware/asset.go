@@ -0,0 +1,147 @@
+// Asset registry: governance and genesis register Axelar assets under their Axelar symbol,
+// e.g. uusdc or axlUSDC, with the local IBC denom of their vouchers, their decimals and the
+// Axelar channel they arrive on. Outbound sends and express executions accept a token named by
+// either form, and handlers resolve the delivered denom with GetAssetByDenom or a symbol with
+// ResolveCoin. Symbols and denoms therefore share one namespace: a symbol can be neither a
+// local denom nor the denom of another asset.
+
+package gmp_middleware
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+// Validate performs basic validation of an asset.
+func (a Asset) Validate() error {
+	if a.Symbol == "" {
+		return fmt.Errorf("asset symbol is required")
+	}
+	if err := sdk.ValidateDenom(a.Symbol); err != nil {
+		return fmt.Errorf("invalid asset symbol %q: %w", a.Symbol, err)
+	}
+	if err := sdk.ValidateDenom(a.Denom); err != nil {
+		return fmt.Errorf("invalid denom %q of asset %s: %w", a.Denom, a.Symbol, err)
+	}
+	if a.Denom == a.Symbol {
+		return fmt.Errorf("denom of asset %s must differ from its symbol", a.Symbol)
+	}
+	if err := host.ChannelIdentifierValidator(a.ChannelId); err != nil {
+		return fmt.Errorf("invalid channel %q of asset %s: %w", a.ChannelId, a.Symbol, err)
+	}
+	return nil
+}
+
+// ResolveDenom returns the local denom of the registered asset with the given symbol, or
+// symbolOrDenom itself if no asset uses it as symbol.
+func (k Keeper) ResolveDenom(ctx sdk.Context, symbolOrDenom string) string {
+	if asset, found := k.GetAsset(ctx, symbolOrDenom); found {
+		return asset.Denom
+	}
+	return symbolOrDenom
+}
+
+// ResolveCoin returns coin in the local denom, resolving an Axelar asset symbol.
+func (k Keeper) ResolveCoin(ctx sdk.Context, coin sdk.Coin) sdk.Coin {
+	if coin.Denom == "" {
+		return coin
+	}
+	return sdk.Coin{Denom: k.ResolveDenom(ctx, coin.Denom), Amount: coin.Amount}
+}
+
+// RegisterAsset adds or replaces an asset. A denom can only be registered under one symbol,
+// the symbol must not be a local denom or the denom of another asset, so that ResolveDenom
+// never shadows a denom, and the asset must arrive on a configured Axelar channel.
+func (k Keeper) RegisterAsset(ctx sdk.Context, asset Asset) error {
+	if !k.GetParams(ctx).IsAxelarChannel(transfertypes.PortID, asset.ChannelId) {
+		return errorsmod.Wrapf(ErrUnauthorizedChannel, "channel %s of asset %s", asset.ChannelId, asset.Symbol)
+	}
+
+	denomIndex := k.assetDenomIndexStore(ctx)
+	if symbol := denomIndex.Get([]byte(asset.Denom)); symbol != nil && string(symbol) != asset.Symbol {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "denom %s is already registered as %s", asset.Denom, symbol)
+	}
+	if _, found := k.GetAsset(ctx, asset.Denom); found {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "denom %s is the symbol of a registered asset", asset.Denom)
+	}
+	if denomIndex.Has([]byte(asset.Symbol)) {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "symbol %s is the denom of a registered asset", asset.Symbol)
+	}
+	if k.bankKeeper.HasSupply(ctx, asset.Symbol) {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "symbol %s is a local denom", asset.Symbol)
+	}
+
+	if old, found := k.GetAsset(ctx, asset.Symbol); found {
+		k.assetDenomIndexStore(ctx).Delete([]byte(old.Denom))
+	}
+	k.SetAsset(ctx, asset)
+	return nil
+}
+
+// DeleteAsset removes the asset with the given symbol and reports whether it existed.
+func (k Keeper) DeleteAsset(ctx sdk.Context, symbol string) bool {
+	asset, found := k.GetAsset(ctx, symbol)
+	if !found {
+		return false
+	}
+	k.assetStore(ctx).Delete([]byte(symbol))
+	k.assetDenomIndexStore(ctx).Delete([]byte(asset.Denom))
+	return true
+}
+
+// GetAsset returns the asset with the given symbol.
+func (k Keeper) GetAsset(ctx sdk.Context, symbol string) (Asset, bool) {
+	bz := k.assetStore(ctx).Get([]byte(symbol))
+	if bz == nil {
+		return Asset{}, false
+	}
+
+	var asset Asset
+	k.cdc.MustUnmarshal(bz, &asset)
+	return asset, true
+}
+
+// GetAssetByDenom returns the asset registered with the given local denom.
+func (k Keeper) GetAssetByDenom(ctx sdk.Context, denom string) (Asset, bool) {
+	symbol := k.assetDenomIndexStore(ctx).Get([]byte(denom))
+	if symbol == nil {
+		return Asset{}, false
+	}
+	return k.GetAsset(ctx, string(symbol))
+}
+
+// SetAsset stores an asset and indexes it by denom.
+func (k Keeper) SetAsset(ctx sdk.Context, asset Asset) {
+	k.assetStore(ctx).Set([]byte(asset.Symbol), k.cdc.MustMarshal(&asset))
+	k.assetDenomIndexStore(ctx).Set([]byte(asset.Denom), []byte(asset.Symbol))
+}
+
+// GetAllAssets returns every registered asset.
+func (k Keeper) GetAllAssets(ctx sdk.Context) []Asset {
+	iterator := k.assetStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var assets []Asset
+	for ; iterator.Valid(); iterator.Next() {
+		var asset Asset
+		k.cdc.MustUnmarshal(iterator.Value(), &asset)
+		assets = append(assets, asset)
+	}
+	return assets
+}
+
+func (k Keeper) assetStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), AssetKeyPrefix)
+}
+
+func (k Keeper) assetDenomIndexStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), AssetDenomIndexKeyPrefix)
+}
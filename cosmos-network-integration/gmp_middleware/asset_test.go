@@ -0,0 +1,138 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func testAsset() Asset {
+	return Asset{Symbol: "uaxl", Denom: testDenom(), Decimals: 6, ChannelId: testChannel}
+}
+
+func TestRegisterAsset(t *testing.T) {
+	f := newTestFixture(t)
+	asset := testAsset()
+	require.NoError(t, f.keeper.RegisterAsset(f.ctx, asset))
+
+	found, ok := f.keeper.GetAsset(f.ctx, asset.Symbol)
+	require.True(t, ok)
+	require.Equal(t, asset, found)
+	found, ok = f.keeper.GetAssetByDenom(f.ctx, asset.Denom)
+	require.True(t, ok)
+	require.Equal(t, asset, found)
+
+	require.Equal(t, sdk.NewInt64Coin(testDenom(), 5), f.keeper.ResolveCoin(f.ctx, sdk.NewInt64Coin("uaxl", 5)))
+	require.Equal(t, sdk.NewInt64Coin("uatom", 5), f.keeper.ResolveCoin(f.ctx, sdk.NewInt64Coin("uatom", 5)))
+	require.Equal(t, testDenom(), f.keeper.ResolveDenom(f.ctx, testDenom()))
+
+	// replacing the denom drops the old index entry
+	asset.Denom = "ibc/0000000000000000000000000000000000000000000000000000000000000001"
+	require.NoError(t, f.keeper.RegisterAsset(f.ctx, asset))
+	_, ok = f.keeper.GetAssetByDenom(f.ctx, testDenom())
+	require.False(t, ok)
+	require.Equal(t, []Asset{asset}, f.keeper.GetAllAssets(f.ctx))
+
+	require.True(t, f.keeper.DeleteAsset(f.ctx, asset.Symbol))
+	require.False(t, f.keeper.DeleteAsset(f.ctx, asset.Symbol))
+	_, ok = f.keeper.GetAssetByDenom(f.ctx, asset.Denom)
+	require.False(t, ok)
+}
+
+func TestRegisterAssetRequiresAxelarChannel(t *testing.T) {
+	f := newTestFixture(t)
+	asset := testAsset()
+	asset.ChannelId = "channel-9"
+
+	require.ErrorIs(t, f.keeper.RegisterAsset(f.ctx, asset), ErrUnauthorizedChannel)
+	require.Empty(t, f.keeper.GetAllAssets(f.ctx))
+}
+
+func TestRegisterAssetKeepsSymbolsApartFromDenoms(t *testing.T) {
+	f := newTestFixture(t)
+	require.NoError(t, f.keeper.RegisterAsset(f.ctx, testAsset()))
+
+	for name, asset := range map[string]Asset{
+		"denom registered under another symbol": {Symbol: "axlUAXL", Denom: testDenom(), ChannelId: testChannel},
+		"denom is a registered symbol":          {Symbol: "uusdc", Denom: "uaxl", ChannelId: testChannel},
+		"symbol is a registered denom":          {Symbol: testDenom(), Denom: "ibc/0000000000000000000000000000000000000000000000000000000000000001", ChannelId: testChannel},
+		"symbol is a local denom":               {Symbol: "stake", Denom: "ibc/0000000000000000000000000000000000000000000000000000000000000002", ChannelId: testChannel},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f.bank.mint(testAddr("holder"), sdk.NewCoins(sdk.NewInt64Coin("stake", 1)))
+
+			require.ErrorIs(t, f.keeper.RegisterAsset(f.ctx, asset), sdkerrors.ErrInvalidRequest)
+			_, ok := f.keeper.GetAsset(f.ctx, asset.Symbol)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestAssetValidate(t *testing.T) {
+	require.NoError(t, testAsset().Validate())
+
+	for name, update := range map[string]func(*Asset){
+		"symbol missing":        func(a *Asset) { a.Symbol = "" },
+		"invalid symbol":        func(a *Asset) { a.Symbol = "1x" },
+		"invalid denom":         func(a *Asset) { a.Denom = "" },
+		"denom equal to symbol": func(a *Asset) { a.Denom = a.Symbol },
+		"invalid channel":       func(a *Asset) { a.ChannelId = "not a channel" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			asset := testAsset()
+			update(&asset)
+			require.Error(t, asset.Validate())
+		})
+	}
+}
+
+func TestGenesisValidateAssets(t *testing.T) {
+	f := newTestFixture(t)
+	gs := DefaultGenesisState()
+	gs.Params = f.keeper.GetParams(f.ctx)
+	gs.Assets = []Asset{testAsset()}
+	require.NoError(t, gs.Validate())
+
+	gs.Assets[0].ChannelId = "channel-9"
+	require.Error(t, gs.Validate())
+
+	// the second asset's symbol is the denom of the first
+	other := Asset{Symbol: testDenom(), Denom: "ibc/0000000000000000000000000000000000000000000000000000000000000001", ChannelId: testChannel}
+	gs.Assets = []Asset{testAsset(), other}
+	require.Error(t, gs.Validate())
+}
+
+func TestMsgRegisterAsset(t *testing.T) {
+	f := newTestFixture(t)
+	server := NewMsgServerImpl(f.keeper)
+
+	msg := &MsgRegisterAsset{Authority: testAddr("someone").String(), Asset: testAsset()}
+	_, err := server.RegisterAsset(f.ctx, msg)
+	require.Error(t, err)
+
+	msg.Authority = testAuthority.String()
+	msg.Asset.ChannelId = ""
+	_, err = server.RegisterAsset(f.ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	msg.Asset = testAsset()
+	_, err = server.RegisterAsset(f.ctx, msg)
+	require.NoError(t, err)
+
+	// outbound sends accept the symbol
+	sender := testAddr("sender")
+	f.bank.mint(sender, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)))
+	_, err = f.keeper.SendToken(f.ctx, sender, "Ethereum", testSourceAddress, sdk.Coin{Denom: "uaxl", Amount: sdkmath.NewInt(100)})
+	require.NoError(t, err)
+	require.Len(t, f.transfer.transfers, 1)
+	require.Equal(t, sdk.NewInt64Coin(testDenom(), 100), f.transfer.transfers[0].Token)
+
+	_, err = server.RemoveAsset(f.ctx, &MsgRemoveAsset{Authority: testAuthority.String(), Symbol: "uaxl"})
+	require.NoError(t, err)
+	_, err = server.RemoveAsset(f.ctx, &MsgRemoveAsset{Authority: testAuthority.String(), Symbol: "uaxl"})
+	require.ErrorIs(t, err, ErrNoAsset)
+}
@@ -0,0 +1,165 @@
+package gmp_middleware
+
+import (
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+)
+
+// AutoCLIOptions implements the autocli.HasAutoCLIConfig interface.
+func (am AppModule) AutoCLIOptions() *autocliv1.ModuleOptions {
+	return &autocliv1.ModuleOptions{
+		Query: &autocliv1.ServiceCommandDescriptor{
+			Service: "gmp.v1.Query",
+			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+				{
+					RpcMethod: "Params",
+					Use:       "params",
+					Short:     "Query the current gmp module parameters",
+				},
+				{
+					RpcMethod: "Pauses",
+					Use:       "pauses",
+					Short:     "Query the active gmp pause switches",
+				},
+				{
+					RpcMethod: "RateLimits",
+					Use:       "rate-limits",
+					Short:     "Query the gmp rate limits and their usage in the current window",
+				},
+				{
+					RpcMethod: "Messages",
+					Use:       "messages",
+					Short:     "List the inbound GMP message history, filtered by source, destination, status or height",
+				},
+				{
+					RpcMethod:      "Message",
+					Use:            "message [id]",
+					Short:          "Query an inbound GMP message by its hex encoded id",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "id"}},
+				},
+				{
+					RpcMethod: "MessageByPacket",
+					Use:       "message-by-packet [channel-id] [sequence]",
+					Short:     "Query the inbound GMP message delivered by a packet",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "channel_id"},
+						{ProtoField: "sequence"},
+					},
+				},
+				{
+					RpcMethod:      "MessagesByPayloadHash",
+					Use:            "messages-by-payload [payload-hash]",
+					Short:          "List the inbound GMP messages carrying a payload, by its hex encoded sha256 hash",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "payload_hash"}},
+				},
+				{
+					RpcMethod: "FailedMessages",
+					Use:       "failed-messages",
+					Short:     "List the failed GMP messages stored for retry",
+				},
+				{
+					RpcMethod: "Refunds",
+					Use:       "refunds",
+					Short:     "List the refunds of failed GMP messages, optionally filtered by status",
+				},
+				{
+					RpcMethod:      "Refund",
+					Use:            "refund [id]",
+					Short:          "Query the refund of a failed GMP message by its hex encoded id",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "id"}},
+				},
+				{
+					RpcMethod: "Assets",
+					Use:       "assets",
+					Short:     "List the registered Axelar assets",
+				},
+				{
+					RpcMethod:      "AssetBySymbol",
+					Use:            "asset [symbol]",
+					Short:          "Query a registered Axelar asset by its symbol",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "symbol"}},
+				},
+				{
+					RpcMethod:      "AssetByDenom",
+					Use:            "asset-by-denom [denom]",
+					Short:          "Query a registered Axelar asset by its local denom",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "denom"}},
+				},
+			},
+		},
+		Tx: &autocliv1.ServiceCommandDescriptor{
+			Service: "gmp.v1.Msg",
+			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+				{
+					RpcMethod: "UpdateParams",
+					Skip:      true, // skipped because authority gated
+				},
+				{
+					RpcMethod: "SetRateLimit",
+					Skip:      true, // skipped because authority gated
+				},
+				{
+					RpcMethod: "RemoveRateLimit",
+					Skip:      true, // skipped because authority gated
+				},
+				{
+					RpcMethod: "RegisterAsset",
+					Skip:      true, // skipped because authority gated
+				},
+				{
+					RpcMethod: "RemoveAsset",
+					Skip:      true, // skipped because authority gated
+				},
+				{
+					RpcMethod: "ClaimRecovery",
+					Use:       "claim-recovery [source-chain] [source-address]",
+					Short:     "Claim the tokens of failed GMP messages held in recovery escrow",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "source_chain"},
+						{ProtoField: "source_address"},
+					},
+				},
+				{
+					RpcMethod: "ExpressExecute",
+					Use:       "express-execute [source-chain] [source-address] [destination-address] [payload] [message-id]",
+					Short:     "Execute an inbound GMP message ahead of its packet as an express executor",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "source_chain"},
+						{ProtoField: "source_address"},
+						{ProtoField: "destination_address"},
+						{ProtoField: "payload"},
+						{ProtoField: "message_id"},
+					},
+				},
+				{
+					RpcMethod:      "RetryGeneralMessage",
+					Use:            "retry [id]",
+					Short:          "Retry a failed GMP message stored for retry, by its hex encoded id",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "id"}},
+				},
+				{
+					RpcMethod:      "RetryRefund",
+					Use:            "retry-refund [id]",
+					Short:          "Send a failed refund of a GMP message again, by its hex encoded id",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "id"}},
+				},
+				{
+					RpcMethod: "Pause",
+					Use:       "pause [scope]",
+					Short:     "Pause inbound GMP traffic for a scope, as a circuit breaker account",
+					Long:      "Pause inbound GMP traffic. The scope is one of PAUSE_SCOPE_ALL, PAUSE_SCOPE_SOURCE_CHAIN, PAUSE_SCOPE_SOURCE_ADDRESS or PAUSE_SCOPE_MESSAGE_TYPE, narrowed with the --source-chain, --source-address and --message-type flags.",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "scope"},
+					},
+				},
+				{
+					RpcMethod: "Unpause",
+					Use:       "unpause [scope]",
+					Short:     "Resume inbound GMP traffic for a scope, as a circuit breaker account",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "scope"},
+					},
+				},
+			},
+		},
+	}
+}
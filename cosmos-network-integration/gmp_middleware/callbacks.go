@@ -0,0 +1,217 @@
+// Outbound GMP callbacks: a module that sends a GMP message can ask to be told how the
+// underlying IBC packet ended. Modules register a GMPCallbacks implementation under their name
+// during app wiring, and attach that name to a packet right after sending it. The middleware
+// then calls the module back once the packet is acknowledged or times out.
+
+package gmp_middleware
+
+import (
+	"errors"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// GMPCallbacks is implemented by modules that want to learn the outcome of GMP messages they sent.
+// Callbacks run like handlers, in a cached context under the max_handler_gas limit: an error,
+// a panic or running out of gas discards their state changes but never fails the
+// acknowledgement or timeout itself.
+type GMPCallbacks interface {
+	// OnGMPAck is called when Axelar acknowledged the packet successfully.
+	OnGMPAck(ctx sdk.Context, channelID string, sequence uint64, result []byte) error
+	// OnGMPError is called when Axelar returned an error acknowledgement.
+	OnGMPError(ctx sdk.Context, channelID string, sequence uint64, errMsg string) error
+	// OnGMPTimeout is called when the packet timed out before reaching Axelar.
+	OnGMPTimeout(ctx sdk.Context, channelID string, sequence uint64) error
+}
+
+// RegisterCallbacks registers the callbacks of the named module.
+// It must be called during app wiring and panics if the name is already taken.
+func (k *Keeper) RegisterCallbacks(module string, callbacks GMPCallbacks) {
+	if k.callbacks == nil {
+		k.callbacks = make(map[string]GMPCallbacks)
+	}
+	if _, ok := k.callbacks[module]; ok {
+		panic(fmt.Sprintf("gmp callbacks already registered for module %s", module))
+	}
+	k.callbacks[module] = callbacks
+}
+
+// SetPacketCallback asks for module to be called back once the outbound GMP packet with the
+// given sequence is acknowledged or times out. It must be called in the same transaction
+// as SendGeneralMessage or SendGeneralMessageWithToken, and only accepts the sequence of a GMP
+// packet that is still in flight.
+func (k Keeper) SetPacketCallback(ctx sdk.Context, sequence uint64, module string) error {
+	if _, ok := k.callbacks[module]; !ok {
+		return errorsmod.Wrapf(ErrNoCallbacks, "module %s", module)
+	}
+
+	channelID := k.GetParams(ctx).OutboundChannelId
+	if channelID == "" {
+		return errorsmod.Wrap(ErrOutboundDisabled, "outbound channel not set")
+	}
+
+	key := packetKey(channelID, sequence)
+	if !k.outboundPacketStore(ctx).Has(key) {
+		return errorsmod.Wrapf(ErrUnknownPacket, "%s/%d", channelID, sequence)
+	}
+
+	k.packetCallbackStore(ctx).Set(key, []byte(module))
+	return nil
+}
+
+// OnAcknowledgementPacket reports an acknowledged outbound GMP packet and invokes the
+// callbacks attached to it.
+func (k Keeper) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte) {
+	var ack channeltypes.Acknowledgement
+	ackErr := transfertypes.ModuleCdc.UnmarshalJSON(acknowledgement, &ack)
+	if ackErr != nil {
+		ackErr = fmt.Errorf("cannot decode acknowledgement: %w", ackErr)
+	} else if !ack.Success() {
+		ackErr = errors.New(ack.GetError())
+	}
+
+	if k.popOutboundPacket(ctx, packet) {
+		event := &EventMessageAcknowledged{
+			ChannelId: packet.SourceChannel,
+			Sequence:  packet.Sequence,
+			Success:   ackErr == nil,
+		}
+		if ackErr != nil {
+			event.Error = ackErr.Error()
+		}
+		k.EmitEvent(ctx, event)
+	}
+	k.onRefundPacket(ctx, packet, ackErr)
+
+	callbacks, ok := k.popPacketCallback(ctx, packet)
+	if !ok {
+		return
+	}
+
+	if ackErr != nil {
+		k.runCallback(ctx, packet, func(cacheCtx sdk.Context) error {
+			return callbacks.OnGMPError(cacheCtx, packet.SourceChannel, packet.Sequence, ackErr.Error())
+		})
+		return
+	}
+
+	k.runCallback(ctx, packet, func(cacheCtx sdk.Context) error {
+		return callbacks.OnGMPAck(cacheCtx, packet.SourceChannel, packet.Sequence, ack.GetResult())
+	})
+}
+
+// OnTimeoutPacket reports a timed out outbound GMP packet and invokes the callbacks attached to it.
+func (k Keeper) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet) {
+	if k.popOutboundPacket(ctx, packet) {
+		k.EmitEvent(ctx, &EventMessageTimedOut{
+			ChannelId: packet.SourceChannel,
+			Sequence:  packet.Sequence,
+		})
+	}
+	k.onRefundPacket(ctx, packet, errors.New("packet timed out"))
+
+	callbacks, ok := k.popPacketCallback(ctx, packet)
+	if !ok {
+		return
+	}
+
+	k.runCallback(ctx, packet, func(cacheCtx sdk.Context) error {
+		return callbacks.OnGMPTimeout(cacheCtx, packet.SourceChannel, packet.Sequence)
+	})
+}
+
+// popPacketCallback returns and deletes the callbacks attached to the packet, if any.
+func (k Keeper) popPacketCallback(ctx sdk.Context, packet channeltypes.Packet) (GMPCallbacks, bool) {
+	store := k.packetCallbackStore(ctx)
+	key := packetKey(packet.SourceChannel, packet.Sequence)
+
+	bz := store.Get(key)
+	if bz == nil {
+		return nil, false
+	}
+	store.Delete(key)
+
+	callbacks, ok := k.callbacks[string(bz)]
+	if !ok {
+		k.Logger(ctx).Error("gmp callbacks not registered", "module", string(bz), "channel", packet.SourceChannel, "sequence", packet.Sequence)
+		return nil, false
+	}
+	return callbacks, true
+}
+
+// runCallback executes fn like a handler, with panic recovery and the max_handler_gas limit,
+// and only commits its state changes if it succeeds.
+func (k Keeper) runCallback(ctx sdk.Context, packet channeltypes.Packet, fn func(sdk.Context) error) {
+	if _, err := k.ExecuteHandler(ctx, k.GetParams(ctx).MaxHandlerGas, fn); err != nil {
+		k.Logger(ctx).Error("gmp callback failed", "channel", packet.SourceChannel, "sequence", packet.Sequence, "error", err)
+	}
+}
+
+// setOutboundPacket records an outbound GMP packet until it is acknowledged or times out.
+func (k Keeper) setOutboundPacket(ctx sdk.Context, channelID string, sequence uint64) {
+	k.outboundPacketStore(ctx).Set(packetKey(channelID, sequence), []byte{})
+}
+
+// popOutboundPacket deletes the record of an outbound GMP packet and reports whether it existed.
+// Acks and timeouts are matched against the record, not the current params, so a packet still
+// in flight when the outbound channel or GMP account changes is reported all the same.
+func (k Keeper) popOutboundPacket(ctx sdk.Context, packet channeltypes.Packet) bool {
+	store := k.outboundPacketStore(ctx)
+	key := packetKey(packet.SourceChannel, packet.Sequence)
+	if !store.Has(key) {
+		return false
+	}
+	store.Delete(key)
+	return true
+}
+
+// GetAllOutboundPackets returns every outbound GMP packet awaiting its ack or timeout.
+func (k Keeper) GetAllOutboundPackets(ctx sdk.Context) []OutboundPacket {
+	iterator := k.outboundPacketStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var packets []OutboundPacket
+	for ; iterator.Valid(); iterator.Next() {
+		channelID, sequence := splitPacketKey(iterator.Key())
+		packets = append(packets, OutboundPacket{ChannelId: channelID, Sequence: sequence})
+	}
+	return packets
+}
+
+// GetAllPacketCallbacks returns every callback attached to an outbound GMP packet.
+func (k Keeper) GetAllPacketCallbacks(ctx sdk.Context) []PacketCallback {
+	iterator := k.packetCallbackStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var callbacks []PacketCallback
+	for ; iterator.Valid(); iterator.Next() {
+		channelID, sequence := splitPacketKey(iterator.Key())
+		callbacks = append(callbacks, PacketCallback{ChannelId: channelID, Sequence: sequence, Module: string(iterator.Value())})
+	}
+	return callbacks
+}
+
+func (k Keeper) packetCallbackStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), PacketCallbackKeyPrefix)
+}
+
+func (k Keeper) outboundPacketStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), OutboundPacketKeyPrefix)
+}
+
+// packetKey returns the key of an outbound packet: <channel_id>/<sequence>
+func packetKey(channelID string, sequence uint64) []byte {
+	return append([]byte(channelID+"/"), sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// splitPacketKey returns the channel and sequence of a key built by packetKey.
+func splitPacketKey(key []byte) (string, uint64) {
+	return string(key[:len(key)-9]), sdk.BigEndianToUint64(key[len(key)-8:])
+}
@@ -0,0 +1,140 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// mockCallbacks records the outcomes it is told about and runs fn on each of them.
+type mockCallbacks struct {
+	acks, errors, timeouts []uint64
+	fn                     func(sdk.Context)
+}
+
+func (c *mockCallbacks) run(ctx sdk.Context) {
+	if c.fn != nil {
+		c.fn(ctx)
+	}
+}
+
+func (c *mockCallbacks) OnGMPAck(ctx sdk.Context, _ string, sequence uint64, _ []byte) error {
+	c.acks = append(c.acks, sequence)
+	c.run(ctx)
+	return nil
+}
+
+func (c *mockCallbacks) OnGMPError(ctx sdk.Context, _ string, sequence uint64, _ string) error {
+	c.errors = append(c.errors, sequence)
+	c.run(ctx)
+	return nil
+}
+
+func (c *mockCallbacks) OnGMPTimeout(ctx sdk.Context, _ string, sequence uint64) error {
+	c.timeouts = append(c.timeouts, sequence)
+	c.run(ctx)
+	return nil
+}
+
+// sendPacket sends a general message and returns its outbound packet.
+func (f *testFixture) sendPacket(t *testing.T) channeltypes.Packet {
+	t.Helper()
+
+	sender := testAddr("sender")
+	fee := sdk.NewInt64Coin("stake", 10)
+	f.bank.mint(sender, sdk.NewCoins(fee))
+
+	sequence, err := f.keeper.SendGeneralMessage(f.ctx, sender, "Ethereum", testSourceAddress, []byte("payload"), fee, "")
+	require.NoError(t, err)
+
+	transfer := f.transfer.transfers[len(f.transfer.transfers)-1]
+	data := transfertypes.NewFungibleTokenPacketData(fee.Denom, fee.Amount.String(), transfer.Sender, transfer.Receiver, transfer.Memo)
+	return channeltypes.NewPacket(data.GetBytes(), sequence, transfertypes.PortID, transfer.SourceChannel,
+		transfertypes.PortID, "channel-7", transfer.TimeoutHeight, transfer.TimeoutTimestamp)
+}
+
+// sendWithCallback sends a general message and attaches the "caller" callbacks to its packet.
+func (f *testFixture) sendWithCallback(t *testing.T) channeltypes.Packet {
+	t.Helper()
+
+	packet := f.sendPacket(t)
+	require.NoError(t, f.keeper.SetPacketCallback(f.ctx, packet.Sequence, "caller"))
+	return packet
+}
+
+func TestPacketCallbacks(t *testing.T) {
+	f := newTestFixture(t)
+	callbacks := &mockCallbacks{}
+	f.keeper.RegisterCallbacks("caller", callbacks)
+
+	packet := f.sendWithCallback(t)
+	f.keeper.OnAcknowledgementPacket(f.ctx, packet, channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement())
+	require.Equal(t, []uint64{packet.Sequence}, callbacks.acks)
+
+	packet = f.sendWithCallback(t)
+	f.keeper.OnAcknowledgementPacket(f.ctx, packet, channeltypes.NewErrorAcknowledgement(ErrHandlerFailed).Acknowledgement())
+	require.Equal(t, []uint64{packet.Sequence}, callbacks.errors)
+
+	packet = f.sendWithCallback(t)
+	f.keeper.OnTimeoutPacket(f.ctx, packet)
+	require.Equal(t, []uint64{packet.Sequence}, callbacks.timeouts)
+
+	// callbacks run once, and completed packets cannot take new callbacks
+	f.keeper.OnTimeoutPacket(f.ctx, packet)
+	require.Len(t, callbacks.timeouts, 1)
+	require.ErrorIs(t, f.keeper.SetPacketCallback(f.ctx, packet.Sequence, "caller"), ErrUnknownPacket)
+}
+
+func TestSetPacketCallbackRequiresGMPPacket(t *testing.T) {
+	f := newTestFixture(t)
+	f.keeper.RegisterCallbacks("caller", &mockCallbacks{})
+
+	require.ErrorIs(t, f.keeper.SetPacketCallback(f.ctx, 42, "caller"), ErrUnknownPacket)
+	require.ErrorIs(t, f.keeper.SetPacketCallback(f.ctx, 42, "unknown"), ErrNoCallbacks)
+}
+
+func TestPacketCallbackPanicsAndGas(t *testing.T) {
+	f := newTestFixture(t)
+	key := []byte("callback")
+	callbacks := &mockCallbacks{}
+	f.keeper.RegisterCallbacks("caller", callbacks)
+
+	callbacks.fn = func(ctx sdk.Context) {
+		f.keeper.outboundPacketStore(ctx).Set(key, []byte{1})
+		panic("callback panicked")
+	}
+	packet := f.sendWithCallback(t)
+	require.NotPanics(t, func() {
+		f.keeper.OnAcknowledgementPacket(f.ctx, packet, channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement())
+	})
+	require.False(t, f.keeper.outboundPacketStore(f.ctx).Has(key))
+
+	callbacks.fn = func(ctx sdk.Context) {
+		ctx.GasMeter().ConsumeGas(f.keeper.GetParams(ctx).MaxHandlerGas+1, "callback")
+	}
+	packet = f.sendWithCallback(t)
+	require.NotPanics(t, func() { f.keeper.OnTimeoutPacket(f.ctx, packet) })
+	require.Len(t, callbacks.timeouts, 1)
+}
+
+func TestPacketCallbackGenesis(t *testing.T) {
+	f := newTestFixture(t)
+	f.keeper.RegisterCallbacks("caller", &mockCallbacks{})
+	packet := f.sendWithCallback(t)
+
+	gs := f.keeper.ExportGenesis(f.ctx)
+	require.NoError(t, gs.Validate())
+	require.Equal(t, []OutboundPacket{{ChannelId: testChannel, Sequence: packet.Sequence}}, gs.OutboundPackets)
+	require.Equal(t, []PacketCallback{{ChannelId: testChannel, Sequence: packet.Sequence, Module: "caller"}}, gs.PacketCallbacks)
+
+	imported := newTestFixture(t)
+	imported.keeper.InitGenesis(imported.ctx, *gs)
+	require.Equal(t, gs.PacketCallbacks, imported.keeper.GetAllPacketCallbacks(imported.ctx))
+
+	gs.OutboundPackets = nil
+	require.Error(t, gs.Validate())
+}
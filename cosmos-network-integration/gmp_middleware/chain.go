@@ -0,0 +1,204 @@
+// Chained deliveries: a message that carries tokens to the delivery account may name a next hop
+// in its memo, using the same shapes as the packet-forward and ibc-hooks memos:
+//
+//	"next": {"forward": {"receiver": "...", "port": "transfer", "channel": "channel-1", "timeout": "10m", "next": {...}}}
+//	"next": {"wasm": {"contract": "...", "msg": {...}}}
+//
+// Once the handler succeeded, the part of the delivered tokens still held by the delivery
+// account is forwarded over IBC or sent to the contract along with its execute message, so
+// tokens the handler paid out are not spent a second time. The hop runs in the handler's
+// cached context, so a failing hop fails the message like a failing handler. Both hops act from
+// an account derived from the message's source chain and address, never from the delivery
+// account itself, so the refund of a forward that times out or is rejected returns to it.
+
+package gmp_middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+// NextHop is the hop a delivery chains into after its handler. Exactly one field is set.
+type NextHop struct {
+	Forward *ForwardHop `json:"forward,omitempty"`
+	Wasm    *WasmHop    `json:"wasm,omitempty"`
+}
+
+// ForwardHop forwards the delivered tokens over IBC, like a packet-forward memo.
+type ForwardHop struct {
+	Receiver string `json:"receiver"`
+	Port     string `json:"port,omitempty"`
+	Channel  string `json:"channel"`
+	// Timeout is a duration such as "10m". It defaults to the outbound timeout param.
+	Timeout string `json:"timeout,omitempty"`
+	// Next is passed on as the memo of the forwarded transfer.
+	Next json.RawMessage `json:"next,omitempty"`
+}
+
+// WasmHop executes a CosmWasm contract with the delivered tokens, like an ibc-hooks memo.
+type WasmHop struct {
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+}
+
+// Validate ensures that the hop is well formed.
+func (h NextHop) Validate() error {
+	switch {
+	case h.Forward != nil && h.Wasm != nil:
+		return fmt.Errorf("next hop cannot both forward and call a contract")
+	case h.Forward != nil:
+		if h.Forward.Receiver == "" {
+			return fmt.Errorf("forward receiver is required")
+		}
+		if h.Forward.Port != "" {
+			if err := host.PortIdentifierValidator(h.Forward.Port); err != nil {
+				return fmt.Errorf("invalid forward port: %w", err)
+			}
+		}
+		if err := host.ChannelIdentifierValidator(h.Forward.Channel); err != nil {
+			return fmt.Errorf("invalid forward channel: %w", err)
+		}
+		if h.Forward.Timeout != "" {
+			if timeout, err := time.ParseDuration(h.Forward.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid forward timeout %q", h.Forward.Timeout)
+			}
+		}
+	case h.Wasm != nil:
+		if _, err := sdk.AccAddressFromBech32(h.Wasm.Contract); err != nil {
+			return fmt.Errorf("invalid wasm contract: %w", err)
+		}
+		if !json.Valid(h.Wasm.Msg) || !bytes.HasPrefix(bytes.TrimSpace(h.Wasm.Msg), []byte("{")) {
+			return fmt.Errorf("wasm msg must be a JSON object")
+		}
+	default:
+		return fmt.Errorf("next hop must forward or call a contract")
+	}
+	return nil
+}
+
+// SenderAddress returns the local account that acts for sourceAddress on sourceChain when a
+// chained delivery forwards tokens or calls a contract.
+func SenderAddress(sourceChain, sourceAddress string) sdk.AccAddress {
+	return address.Module(ModuleName, []byte(sourceChain), []byte(sourceAddress))
+}
+
+// ExecuteNextHop moves coin, delivered to receiver for msg, on to the next hop of msg.
+func (k Keeper) ExecuteNextHop(ctx sdk.Context, msg Message, receiver string, coin sdk.Coin) error {
+	if msg.Type == TypeGeneralMessage {
+		return errorsmod.Wrap(ErrInvalidMessage, "messages without token cannot chain into a next hop")
+	}
+
+	deliveryAddr := DeliveryAddress()
+	if receiver != deliveryAddr.String() {
+		return errorsmod.Wrapf(ErrInvalidMessage, "chained deliveries must be addressed to the gmp delivery account, not %s", receiver)
+	}
+
+	// The hop spends from the sender account, which also receives the refund of a failed forward.
+	sender := SenderAddress(msg.SourceChain, msg.SourceAddress)
+	coins := sdk.NewCoins(coin)
+	if err := k.bankKeeper.SendCoins(ctx, deliveryAddr, sender, coins); err != nil {
+		return err
+	}
+
+	hop := msg.Next
+	event := &EventMessageChained{
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Denom:         coin.Denom,
+		Amount:        coin.Amount.String(),
+	}
+
+	switch {
+	case hop.Forward != nil:
+		port := hop.Forward.Port
+		if port == "" {
+			port = transfertypes.PortID
+		}
+		timeout := k.GetParams(ctx).OutboundTimeout
+		if hop.Forward.Timeout != "" {
+			var err error
+			if timeout, err = time.ParseDuration(hop.Forward.Timeout); err != nil {
+				return errorsmod.Wrapf(ErrInvalidMessage, "invalid forward timeout: %s", err)
+			}
+		}
+
+		res, err := k.transferKeeper.Transfer(ctx, transfertypes.NewMsgTransfer(
+			port,
+			hop.Forward.Channel,
+			coin,
+			sender.String(),
+			hop.Forward.Receiver,
+			clienttypes.ZeroHeight(),
+			uint64(ctx.BlockTime().Add(timeout).UnixNano()),
+			string(hop.Forward.Next),
+		))
+		if err != nil {
+			return errorsmod.Wrap(err, "cannot forward gmp tokens")
+		}
+
+		event.Kind = MemoKindForward.String()
+		event.Target = hop.Forward.Receiver
+		event.ChannelId = hop.Forward.Channel
+		event.Sequence = res.Sequence
+	case hop.Wasm != nil:
+		if k.contractKeeper == nil {
+			return errorsmod.Wrap(ErrInvalidMessage, "wasm hops are not supported")
+		}
+		contract, err := sdk.AccAddressFromBech32(hop.Wasm.Contract)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "invalid wasm contract: %s", err)
+		}
+
+		if _, err := k.contractKeeper.Execute(ctx, contract, sender, hop.Wasm.Msg, coins); err != nil {
+			return errorsmod.Wrap(err, "wasm hop failed")
+		}
+
+		event.Kind = MemoKindWasm.String()
+		event.Target = hop.Wasm.Contract
+	default:
+		return errorsmod.Wrap(ErrInvalidMessage, "empty next hop")
+	}
+
+	k.EmitEvent(ctx, event)
+	return nil
+}
+
+// chainNextHop wraps execute so that the next hop of msg, if any, runs after it succeeded. The
+// hop moves what is left of coin with the receiver: whatever execute paid out of the receiver
+// is deducted, and nothing is moved once all of coin was spent.
+func (k Keeper) chainNextHop(execute func(sdk.Context) error, msg Message, receiver string, coin sdk.Coin) func(sdk.Context) error {
+	if msg.Next == nil {
+		return execute
+	}
+	return func(ctx sdk.Context) error {
+		receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+		}
+
+		before := k.bankKeeper.GetBalance(ctx, receiverAddr, coin.Denom)
+		if err := execute(ctx); err != nil {
+			return err
+		}
+
+		left := coin
+		if spent := before.Amount.Sub(k.bankKeeper.GetBalance(ctx, receiverAddr, coin.Denom).Amount); spent.IsPositive() {
+			left.Amount = sdkmath.MaxInt(coin.Amount.Sub(spent), sdkmath.ZeroInt())
+		}
+		if left.IsZero() {
+			return nil
+		}
+		return k.ExecuteNextHop(ctx, msg, receiver, left)
+	}
+}
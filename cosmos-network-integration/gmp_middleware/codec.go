@@ -0,0 +1,43 @@
+package gmp_middleware
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+// RegisterLegacyAminoCodec registers the gmp module's concrete types on the LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgUpdateParams{}, "gmp/MsgUpdateParams")
+	legacy.RegisterAminoMsg(cdc, &MsgClaimRecovery{}, "gmp/MsgClaimRecovery")
+	legacy.RegisterAminoMsg(cdc, &MsgExpressExecute{}, "gmp/MsgExpressExecute")
+	legacy.RegisterAminoMsg(cdc, &MsgPause{}, "gmp/MsgPause")
+	legacy.RegisterAminoMsg(cdc, &MsgUnpause{}, "gmp/MsgUnpause")
+	legacy.RegisterAminoMsg(cdc, &MsgSetRateLimit{}, "gmp/MsgSetRateLimit")
+	legacy.RegisterAminoMsg(cdc, &MsgRemoveRateLimit{}, "gmp/MsgRemoveRateLimit")
+	legacy.RegisterAminoMsg(cdc, &MsgRetryGeneralMessage{}, "gmp/MsgRetryGeneralMessage")
+	legacy.RegisterAminoMsg(cdc, &MsgRetryRefund{}, "gmp/MsgRetryRefund")
+	legacy.RegisterAminoMsg(cdc, &MsgRegisterAsset{}, "gmp/MsgRegisterAsset")
+	legacy.RegisterAminoMsg(cdc, &MsgRemoveAsset{}, "gmp/MsgRemoveAsset")
+}
+
+// RegisterInterfaces registers the gmp module's interface types.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgUpdateParams{},
+		&MsgClaimRecovery{},
+		&MsgExpressExecute{},
+		&MsgPause{},
+		&MsgUnpause{},
+		&MsgSetRateLimit{},
+		&MsgRemoveRateLimit{},
+		&MsgRetryGeneralMessage{},
+		&MsgRetryRefund{},
+		&MsgRegisterAsset{},
+		&MsgRemoveAsset{},
+	)
+
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
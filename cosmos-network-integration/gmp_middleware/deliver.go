@@ -0,0 +1,53 @@
+// Token deliveries: a TypeSendToken memo carries no payload and runs no handler. When it names
+// no destination_address, the transfer app already credited the packet receiver and there is
+// nothing left to do. Otherwise the packet must be addressed to the delivery account, which
+// forwards the tokens to the named local recipient. The delivery account is derived from the
+// gmp module rather than being the module account itself, so the module account stays blocked
+// from receiving transfers and its escrow cannot be spent by deliveries.
+
+package gmp_middleware
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
+// DeliveryAddress returns the account that receives the tokens of GMP messages that are
+// forwarded to a destination_address or chained into a next hop.
+func DeliveryAddress() sdk.AccAddress {
+	return address.Module(ModuleName, []byte("delivery"))
+}
+
+// DeliverToken credits coin, received by receiver, to the recipient named in msg.
+func (k Keeper) DeliverToken(ctx sdk.Context, msg Message, receiver string, coin sdk.Coin) error {
+	recipient := msg.DestinationAddress
+	if recipient == "" {
+		recipient = receiver
+	}
+
+	if recipient != receiver {
+		deliveryAddr := DeliveryAddress()
+		if receiver != deliveryAddr.String() {
+			return errorsmod.Wrapf(ErrInvalidMessage, "tokens for %s can only be forwarded by the gmp delivery account, not %s", recipient, receiver)
+		}
+
+		recipientAddr, err := sdk.AccAddressFromBech32(recipient)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "invalid destination address: %s", err)
+		}
+		if err := k.bankKeeper.SendCoins(ctx, deliveryAddr, recipientAddr, sdk.NewCoins(coin)); err != nil {
+			return errorsmod.Wrap(err, "cannot deliver gmp tokens")
+		}
+	}
+
+	k.EmitEvent(ctx, &EventTokenDelivered{
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Recipient:     recipient,
+		Denom:         coin.Denom,
+		Amount:        coin.Amount.String(),
+	})
+	return nil
+}
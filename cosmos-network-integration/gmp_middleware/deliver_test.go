@@ -0,0 +1,184 @@
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// testSendToken returns a TypeSendToken message for recipient.
+func testSendToken(recipient sdk.AccAddress) Message {
+	return Message{
+		SourceChain:        testSourceChain,
+		SourceAddress:      testSourceAddress,
+		Type:               TypeSendToken,
+		DestinationAddress: recipient.String(),
+	}
+}
+
+func TestDeliverTokenThroughDeliveryAccount(t *testing.T) {
+	f := newTestFixture(t)
+	recipient := testAddr("recipient")
+
+	ack := f.recvPacket(t, DeliveryAddress().String(), 100, testSendToken(recipient))
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(recipient))
+	require.True(t, f.bank.balance(DeliveryAddress()).IsZero())
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventTokenDelivered"))
+}
+
+func TestDeliverTokenRejectsOtherReceivers(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver")
+	recipient := testAddr("recipient")
+
+	ack := f.recvPacket(t, receiver.String(), 100, testSendToken(recipient))
+	require.False(t, ack.Success())
+	require.Empty(t, f.bank.balance(recipient))
+}
+
+func TestDeliveriesCannotSpendModuleEscrow(t *testing.T) {
+	f := newTestFixture(t)
+	escrow := sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 500))
+	f.bank.mint(testModuleAddr, escrow)
+
+	// the module account is blocked, so packets cannot be addressed to it
+	ack := f.recvPacket(t, testModuleAddr.String(), 100, testSendToken(testAddr("recipient")))
+	require.False(t, ack.Success())
+
+	// and the delivery account only ever moves what it received
+	forward := testSendToken(DeliveryAddress())
+	forward.DestinationAddress = ""
+	forward.Next = &NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1"}}
+	ack = f.recvPacket(t, DeliveryAddress().String(), 100, forward)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Equal(t, escrow, f.bank.balance(testModuleAddr))
+}
+
+func TestExecuteNextHopForward(t *testing.T) {
+	f := newTestFixture(t)
+	next := json.RawMessage(`{"forward":{"receiver":"osmo1final","channel":"channel-2"}}`)
+
+	msg := testSendToken(DeliveryAddress())
+	msg.DestinationAddress = ""
+	msg.Next = &NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1", Timeout: "5m", Next: next}}
+	ack := f.recvPacket(t, DeliveryAddress().String(), 100, msg)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+
+	require.Len(t, f.transfer.transfers, 1)
+	transfer := f.transfer.transfers[0]
+	// a refund of the forward returns to the source's sender account, not the shared delivery account
+	require.Equal(t, SenderAddress(testSourceChain, testSourceAddress).String(), transfer.Sender)
+	require.Equal(t, "osmo1receiver", transfer.Receiver)
+	require.Equal(t, "channel-1", transfer.SourceChannel)
+	require.Equal(t, sdk.NewInt64Coin(testDenom(), 100), transfer.Token)
+	require.Equal(t, string(next), transfer.Memo)
+	require.True(t, f.bank.balance(DeliveryAddress()).IsZero())
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageChained"))
+}
+
+func TestExecuteNextHopWasm(t *testing.T) {
+	f := newTestFixture(t)
+	contract := testAddr("contract")
+
+	msg := testSendToken(DeliveryAddress())
+	msg.DestinationAddress = ""
+	msg.Next = &NextHop{Wasm: &WasmHop{Contract: contract.String(), Msg: json.RawMessage(`{"deposit":{}}`)}}
+	ack := f.recvPacket(t, DeliveryAddress().String(), 100, msg)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+
+	require.Equal(t, []string{`{"deposit":{}}`}, f.contracts.calls)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(contract))
+	require.True(t, f.bank.balance(DeliveryAddress()).IsZero())
+	require.True(t, f.bank.balance(SenderAddress(testSourceChain, testSourceAddress)).IsZero())
+}
+
+func TestExecuteNextHopRequiresDeliveryAccount(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver")
+
+	msg := testSendToken(receiver)
+	msg.DestinationAddress = ""
+	msg.Next = &NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1"}}
+	ack := f.recvPacket(t, receiver.String(), 100, msg)
+	require.False(t, ack.Success())
+	require.Empty(t, f.transfer.transfers)
+}
+
+func TestNextHopAfterHandler(t *testing.T) {
+	stuck := sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 500))
+	payee := testAddr("payee")
+
+	for name, tc := range map[string]struct {
+		spent     int64
+		forwarded int64
+	}{
+		"nothing spent":       {spent: 0, forwarded: 100},
+		"part spent":          {spent: 30, forwarded: 70},
+		"all spent":           {spent: 100},
+		"more than delivered": {spent: 150},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFixture(t)
+			f.bank.mint(DeliveryAddress(), stuck)
+			f.handler.spend = func(receiver string, coin sdk.Coin) error {
+				spent := sdk.NewCoins(sdk.NewInt64Coin(coin.Denom, tc.spent))
+				return f.bank.SendCoins(f.ctx, sdk.MustAccAddressFromBech32(receiver), payee, spent)
+			}
+
+			msg := testMessage()
+			msg.Next = &NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1"}}
+			ack := f.recvPacket(t, DeliveryAddress().String(), 100, msg)
+			require.True(t, ack.Success(), string(ack.Acknowledgement()))
+			require.Equal(t, []sdk.Coin{sdk.NewInt64Coin(testDenom(), 100)}, f.handler.calls)
+
+			// only what the handler left of the delivered tokens moves on
+			if tc.forwarded == 0 {
+				require.Empty(t, f.transfer.transfers)
+			} else {
+				require.Len(t, f.transfer.transfers, 1)
+				require.Equal(t, sdk.NewInt64Coin(testDenom(), tc.forwarded), f.transfer.transfers[0].Token)
+			}
+			require.Equal(t, stuck.AmountOf(testDenom()).Int64()+100-tc.spent-tc.forwarded,
+				f.bank.balance(DeliveryAddress()).AmountOf(testDenom()).Int64())
+		})
+	}
+}
+
+func TestNextHopRequiresToken(t *testing.T) {
+	f := newTestFixture(t)
+
+	msg := testMessage()
+	msg.Type = TypeGeneralMessage
+	msg.Next = &NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1"}}
+	ack := f.recvPacket(t, DeliveryAddress().String(), 100, msg)
+	require.False(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+
+	err := f.keeper.ExecuteNextHop(f.ctx, msg, DeliveryAddress().String(), sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrInvalidMessage)
+	require.Empty(t, f.transfer.transfers)
+}
+
+func TestNextHopValidate(t *testing.T) {
+	contract := testAddr("contract").String()
+	require.NoError(t, NextHop{Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1", Timeout: "5m"}}.Validate())
+	require.NoError(t, NextHop{Wasm: &WasmHop{Contract: contract, Msg: json.RawMessage(`{"deposit":{}}`)}}.Validate())
+
+	for name, hop := range map[string]NextHop{
+		"empty":             {},
+		"forward and wasm":  {Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1"}, Wasm: &WasmHop{Contract: contract, Msg: json.RawMessage(`{}`)}},
+		"no receiver":       {Forward: &ForwardHop{Channel: "channel-1"}},
+		"invalid channel":   {Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "x"}},
+		"invalid timeout":   {Forward: &ForwardHop{Receiver: "osmo1receiver", Channel: "channel-1", Timeout: "-1m"}},
+		"invalid contract":  {Wasm: &WasmHop{Contract: "contract", Msg: json.RawMessage(`{}`)}},
+		"msg not an object": {Wasm: &WasmHop{Contract: contract, Msg: json.RawMessage(`[]`)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, hop.Validate())
+		})
+	}
+}
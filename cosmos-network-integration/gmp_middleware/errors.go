@@ -0,0 +1,31 @@
+package gmp_middleware
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// gmp module sentinel errors
+var (
+	ErrInvalidParams        = errorsmod.Register(ModuleName, 2, "invalid params")
+	ErrUnauthorizedChannel  = errorsmod.Register(ModuleName, 3, "gmp packet received on a channel not bound to axelar")
+	ErrNoHandler            = errorsmod.Register(ModuleName, 4, "no gmp handler registered for message")
+	ErrInvalidMessage       = errorsmod.Register(ModuleName, 5, "invalid gmp message")
+	ErrOutboundDisabled     = errorsmod.Register(ModuleName, 6, "outbound gmp is not configured")
+	ErrNoCallbacks          = errorsmod.Register(ModuleName, 7, "no gmp callbacks registered for module")
+	ErrDuplicateMessage     = errorsmod.Register(ModuleName, 8, "gmp message already processed")
+	ErrHandlerFailed        = errorsmod.Register(ModuleName, 9, "gmp handler failed")
+	ErrNoRecovery           = errorsmod.Register(ModuleName, 10, "no recovery escrow found")
+	ErrHandlerOutOfGas      = errorsmod.Register(ModuleName, 11, "gmp handler ran out of gas")
+	ErrUnauthorizedExecutor = errorsmod.Register(ModuleName, 12, "not an express executor")
+	ErrAlreadyExpressed     = errorsmod.Register(ModuleName, 13, "gmp message already expressed")
+	ErrExpressMismatch      = errorsmod.Register(ModuleName, 14, "gmp packet does not match express claim")
+	ErrPaused               = errorsmod.Register(ModuleName, 15, "gmp traffic paused")
+	ErrNotPaused            = errorsmod.Register(ModuleName, 16, "gmp traffic not paused")
+	ErrRateLimited          = errorsmod.Register(ModuleName, 17, "gmp rate limit exceeded")
+	ErrNoRateLimit          = errorsmod.Register(ModuleName, 18, "gmp rate limit not found")
+	ErrNoFailedMessage      = errorsmod.Register(ModuleName, 19, "failed gmp message not found")
+	ErrNoRefund             = errorsmod.Register(ModuleName, 20, "gmp refund not found")
+	ErrNoAsset              = errorsmod.Register(ModuleName, 21, "axelar asset not registered")
+	ErrAmbiguousMemo        = errorsmod.Register(ModuleName, 22, "ambiguous transfer memo")
+	ErrUnknownPacket        = errorsmod.Register(ModuleName, 23, "unknown outbound gmp packet")
+)
@@ -0,0 +1,106 @@
+// Typed events: every step of a GMP message's life is emitted as a typed SDK event so that
+// indexers, relayers and explorers can follow it through CometBFT event subscriptions. Inbound
+// events share the attributes of inboundEvent; outbound acknowledgements and timeouts are
+// reported for every packet recorded as an outbound GMP packet when it was sent.
+//
+// Inbound events are emitted on the packet context. When the middleware returns an error
+// acknowledgement, core IBC discards that context and re-emits its events with the
+// "ibccallbackerror-" type prefix: the EventMessageReceived, EventMessageFailed and
+// EventSenderRejected of a rejected packet are only observable under those error types.
+
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// inboundEvent holds the attributes shared by the events of an inbound GMP message.
+type inboundEvent struct {
+	sourceChain   string
+	sourceAddress string
+	destination   string
+	payloadHash   string
+	denom         string
+	amount        string
+	channelID     string
+	sequence      uint64
+	messageType   int64
+}
+
+func newInboundEvent(packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData, msg Message) inboundEvent {
+	return inboundEvent{
+		sourceChain:   msg.SourceChain,
+		sourceAddress: msg.SourceAddress,
+		destination:   data.Receiver,
+		payloadHash:   PayloadHash(msg.Payload),
+		denom:         parseDenom(packet, data.Denom),
+		amount:        data.Amount,
+		channelID:     packet.GetDestChannel(),
+		sequence:      packet.GetSequence(),
+		messageType:   msg.Type,
+	}
+}
+
+func (e inboundEvent) received() *EventMessageReceived {
+	return &EventMessageReceived{
+		SourceChain:   e.sourceChain,
+		SourceAddress: e.sourceAddress,
+		Destination:   e.destination,
+		PayloadHash:   e.payloadHash,
+		Denom:         e.denom,
+		Amount:        e.amount,
+		ChannelId:     e.channelID,
+		Sequence:      e.sequence,
+		MessageType:   e.messageType,
+	}
+}
+
+func (e inboundEvent) executed() *EventMessageExecuted {
+	return &EventMessageExecuted{
+		SourceChain:   e.sourceChain,
+		SourceAddress: e.sourceAddress,
+		Destination:   e.destination,
+		PayloadHash:   e.payloadHash,
+		Denom:         e.denom,
+		Amount:        e.amount,
+		ChannelId:     e.channelID,
+		Sequence:      e.sequence,
+		MessageType:   e.messageType,
+	}
+}
+
+func (e inboundEvent) failed(err error) *EventMessageFailed {
+	return &EventMessageFailed{
+		SourceChain:   e.sourceChain,
+		SourceAddress: e.sourceAddress,
+		Destination:   e.destination,
+		PayloadHash:   e.payloadHash,
+		Denom:         e.denom,
+		Amount:        e.amount,
+		ChannelId:     e.channelID,
+		Sequence:      e.sequence,
+		MessageType:   e.messageType,
+		Error:         err.Error(),
+	}
+}
+
+// PayloadHash returns the hex encoded sha256 hash of a GMP payload, as reported in events.
+func PayloadHash(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// EmitEvent emits a typed gmp event. Events are informational, so a failure to encode one
+// is logged rather than returned.
+func (k Keeper) EmitEvent(ctx sdk.Context, event proto.Message) {
+	if err := ctx.EventManager().EmitTypedEvent(event); err != nil {
+		k.Logger(ctx).Error("failed to emit gmp event", "event", proto.MessageName(event), "error", err)
+	}
+}
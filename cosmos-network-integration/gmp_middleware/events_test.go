@@ -0,0 +1,56 @@
+package gmp_middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+func TestInboundEvents(t *testing.T) {
+	f := newTestFixture(t)
+
+	require.True(t, f.recvPacket(t, testAddr("receiver").String(), 100, testMessage()).Success())
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageReceived"))
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageExecuted"))
+	require.False(t, hasEvent(f.ctx, "gmp.v1.EventMessageFailed"))
+}
+
+func TestInboundEventsOfSettledFailure(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyStoreAndRetry })
+	f.handler.err = errors.New("handler failed")
+
+	// the failure policy settles the message, so the packet context and its events are kept
+	require.True(t, f.recvPacket(t, testAddr("receiver").String(), 100, testMessage()).Success())
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageFailed"))
+	require.False(t, hasEvent(f.ctx, "gmp.v1.EventMessageExecuted"))
+}
+
+func TestOutboundEventsSurviveParamChanges(t *testing.T) {
+	f := newTestFixture(t)
+	acked, timedOut := f.sendPacket(t), f.sendPacket(t)
+
+	// the packets are in flight when the outbound channel and GMP account change
+	f.setParams(t, func(p *Params) {
+		p.AxelarGmpAccounts = []string{testAddr("new-gmp-account").String()}
+		p.AxelarChannels = append(p.AxelarChannels, AxelarChannel{PortId: transfertypes.PortID, ChannelId: "channel-1"})
+		p.OutboundChannelId = "channel-1"
+	})
+
+	f.ctx = f.ctx.WithEventManager(sdk.NewEventManager())
+	f.keeper.OnAcknowledgementPacket(f.ctx, acked, channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement())
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageAcknowledged"))
+
+	f.keeper.OnTimeoutPacket(f.ctx, timedOut)
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageTimedOut"))
+
+	// packets that were not sent as gmp messages, or were already settled, are not reported
+	f.ctx = f.ctx.WithEventManager(sdk.NewEventManager())
+	f.keeper.OnTimeoutPacket(f.ctx, timedOut)
+	require.False(t, hasEvent(f.ctx, "gmp.v1.EventMessageTimedOut"))
+}
@@ -0,0 +1,102 @@
+// Handler execution: GMP handlers run in a branched cache context after the transfer app has
+// credited the tokens, under a gas meter bounded by the max_handler_gas param, and their writes
+// are committed only if they succeed. When a handler fails, the module failure policy decides
+// whether the packet is rejected with an error ack, the tokens are kept in recovery escrow or
+// refunded to the source address, or the message is stored for retry.
+
+package gmp_middleware
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecuteHandler runs fn in a cached context under its own gas meter limited to gasLimit, and
+// commits its state changes only if it succeeds. It returns the gas used by fn, which is also
+// consumed on the packet context's gas meter. Running out of the handler gas limit, as well as
+// any other panic, is reported as an error, so the outcome does not depend on how much gas the
+// relayer provided.
+func (k Keeper) ExecuteHandler(ctx sdk.Context, gasLimit uint64, fn func(sdk.Context) error) (gasUsed uint64, err error) {
+	gasMeter := storetypes.NewGasMeter(gasLimit)
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(gasMeter)
+
+	defer func() {
+		r := recover()
+
+		gasUsed = gasMeter.GasConsumedToLimit()
+		ctx.GasMeter().ConsumeGas(gasUsed, "gmp handler execution")
+
+		if r == nil {
+			return
+		}
+		if _, ok := r.(storetypes.ErrorOutOfGas); ok {
+			err = errorsmod.Wrapf(ErrHandlerOutOfGas, "gas limit %d exceeded", gasLimit)
+			return
+		}
+		err = errorsmod.Wrapf(ErrHandlerFailed, "handler panicked: %v", r)
+	}()
+
+	if err := fn(cacheCtx); err != nil {
+		return 0, errorsmod.Wrap(ErrHandlerFailed, err.Error())
+	}
+
+	writeCache()
+	return 0, nil
+}
+
+// HandleFailure applies the failure policy to the message with replay key id whose handler
+// returned handlerErr. It returns the status of the message if it was settled and the packet
+// can be acknowledged successfully, or the error to put in the error acknowledgement otherwise.
+func (k Keeper) HandleFailure(ctx sdk.Context, id []byte, msg Message, receiver string, coin *sdk.Coin, handlerErr error) (MessageStatus, error) {
+	switch k.GetParams(ctx).FailurePolicy {
+	case FailurePolicyRecoveryEscrow:
+		if coin == nil {
+			return MessageStatusUnspecified, handlerErr
+		}
+
+		if err := k.EscrowForRecovery(ctx, msg, receiver, *coin); err != nil {
+			return MessageStatusUnspecified, errorsmod.Wrapf(handlerErr, "recovery escrow failed: %s", err)
+		}
+
+		k.Logger(ctx).Info("gmp handler failed, tokens kept in recovery escrow",
+			"source_chain", msg.SourceChain,
+			"source_address", msg.SourceAddress,
+			"recovery_address", k.GetParams(ctx).RecoveryAddress,
+			"coin", coin.String(),
+			"error", handlerErr,
+		)
+		return MessageStatusRecoveryEscrowed, nil
+	case FailurePolicyStoreAndRetry:
+		if err := k.StoreFailedMessage(ctx, id, msg, receiver, coin, handlerErr); err != nil {
+			return MessageStatusUnspecified, errorsmod.Wrapf(handlerErr, "storing failed message failed: %s", err)
+		}
+
+		k.Logger(ctx).Info("gmp handler failed, message stored for retry",
+			"source_chain", msg.SourceChain,
+			"source_address", msg.SourceAddress,
+			"error", handlerErr,
+		)
+		return MessageStatusFailedStored, nil
+	case FailurePolicyRefundToSource:
+		if coin == nil || !coin.IsPositive() {
+			return MessageStatusUnspecified, handlerErr
+		}
+
+		if err := k.RefundFromReceiver(ctx, id, msg, receiver, *coin); err != nil {
+			return MessageStatusUnspecified, errorsmod.Wrapf(handlerErr, "refund failed: %s", err)
+		}
+
+		k.Logger(ctx).Info("gmp handler failed, tokens refunded to source",
+			"source_chain", msg.SourceChain,
+			"source_address", msg.SourceAddress,
+			"coin", coin.String(),
+			"error", handlerErr,
+		)
+		return MessageStatusRefunding, nil
+	default:
+		return MessageStatusUnspecified, handlerErr
+	}
+}
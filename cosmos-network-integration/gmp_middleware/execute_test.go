@@ -0,0 +1,61 @@
+package gmp_middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestExecuteHandler(t *testing.T) {
+	f := newTestFixture(t)
+	key := []byte("handler")
+
+	gasUsed, err := f.keeper.ExecuteHandler(f.ctx, 100_000, func(ctx sdk.Context) error {
+		f.keeper.outboundPacketStore(ctx).Set(key, []byte{1})
+		return nil
+	})
+	require.NoError(t, err)
+	require.Positive(t, gasUsed)
+	require.True(t, f.keeper.outboundPacketStore(f.ctx).Has(key))
+
+	_, err = f.keeper.ExecuteHandler(f.ctx, 100_000, func(ctx sdk.Context) error {
+		f.keeper.outboundPacketStore(ctx).Delete(key)
+		return errors.New("failed")
+	})
+	require.ErrorIs(t, err, ErrHandlerFailed)
+	require.True(t, f.keeper.outboundPacketStore(f.ctx).Has(key))
+
+	_, err = f.keeper.ExecuteHandler(f.ctx, 100_000, func(ctx sdk.Context) error {
+		ctx.GasMeter().ConsumeGas(100_001, "test")
+		return nil
+	})
+	require.ErrorIs(t, err, ErrHandlerOutOfGas)
+
+	_, err = f.keeper.ExecuteHandler(f.ctx, 100_000, func(ctx sdk.Context) error {
+		panic("handler panicked")
+	})
+	require.ErrorIs(t, err, ErrHandlerFailed)
+}
+
+func TestOnRecvPacketHandlerGasLimit(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.MaxHandlerGas = 50_000 })
+	receiver := testAddr("receiver").String()
+
+	f.handler.gas = 50_001
+	require.False(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+
+	f.handler.gas = 40_000
+	require.True(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	require.Len(t, f.handler.calls, 1)
+}
+
+func TestDecodeMemoRejectsGasLimit(t *testing.T) {
+	f := newTestFixture(t)
+
+	_, err := f.keeper.DecodeMemo(f.ctx, `{"source_chain":"Ethereum","source_address":"0x1","payload":"AAAACQ==","type":1,"gas_limit":10}`)
+	require.ErrorIs(t, err, ErrInvalidMessage)
+}
@@ -0,0 +1,40 @@
+package gmp_middleware
+
+import (
+	"context"
+
+	circuittypes "cosmossdk.io/x/circuit/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// ChannelKeeper defines the expected IBC channel keeper.
+type ChannelKeeper interface {
+	GetChannelClientState(ctx sdk.Context, portID, channelID string) (string, ibcexported.ClientState, error)
+}
+
+// BankKeeper defines the expected bank keeper.
+type BankKeeper interface {
+	SendCoins(ctx context.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromAccountToModule(ctx context.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	HasSupply(ctx context.Context, denom string) bool
+	GetBalance(ctx context.Context, addr sdk.AccAddress, denom string) sdk.Coin
+}
+
+// CircuitKeeper defines the expected circuit breaker keeper.
+type CircuitKeeper interface {
+	GetPermissions(ctx context.Context, address []byte) (circuittypes.Permissions, error)
+}
+
+// TransferKeeper defines the expected IBC transfer keeper.
+type TransferKeeper interface {
+	Transfer(goCtx context.Context, msg *transfertypes.MsgTransfer) (*transfertypes.MsgTransferResponse, error)
+}
+
+// ContractKeeper defines the expected CosmWasm contract keeper.
+type ContractKeeper interface {
+	Execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
+}
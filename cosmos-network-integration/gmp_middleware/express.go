@@ -0,0 +1,283 @@
+// GMP Express: a registered express executor that sees a GMP call on the source chain can
+// execute it here right away with MsgExpressExecute, funding the delivered token out of its own
+// balance. The execution is recorded as an ExpressClaim under the express key of the message.
+// When a packet with the same express key arrives, the handler is not run again: the delivered
+// tokens are paid to the executor instead. A packet that does not match the claim runs the
+// handler as usual and the executor is not repaid, so only handlers that opt in with
+// ExpressHandler can be expressed. Messages whose packet was already delivered cannot be
+// expressed. Claims expire after the governance-set retention: the message is then recorded as
+// processed, so a late packet is rejected, and the executor is not repaid.
+
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExpressHandler is implemented by the handlers whose messages may be executed ahead of their
+// packet with MsgExpressExecute.
+type ExpressHandler interface {
+	GeneralMessageHandler
+	// AllowExpress reports whether the message from srcChain/srcAddress to destAddress may be
+	// expressed. The handler runs again if the packet does not match the express claim.
+	AllowExpress(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte) bool
+}
+
+// ExpressKey returns the key shared by the express claim of msg and the packet that settles it:
+// the source, type and payload of msg, its destination and the coin handed to the handler,
+// after fees, and its message ID. coin is empty for messages without token.
+func ExpressKey(msg Message, destination string, coin sdk.Coin) []byte {
+	var amount string
+	if !coin.Amount.IsNil() {
+		amount = coin.Amount.String()
+	}
+
+	payloadHash := sha256.Sum256(msg.Payload)
+	return hashParts(
+		[]byte(msg.SourceChain),
+		[]byte(msg.SourceAddress),
+		payloadHash[:],
+		sdk.Uint64ToBigEndian(uint64(msg.Type)),
+		[]byte(destination),
+		[]byte(coin.Denom),
+		[]byte(amount),
+		[]byte(msg.MessageID),
+	)
+}
+
+// ExpressExecute executes msg, addressed to destination, ahead of its packet. If coin is set it
+// is sent from executor to destination first, as the transfer app would have done. coin may
+// name a registered Axelar asset by its symbol.
+func (k Keeper) ExpressExecute(ctx sdk.Context, executor sdk.AccAddress, msg Message, destination string, coin sdk.Coin) error {
+	coin = k.ResolveCoin(ctx, coin)
+	params := k.GetParams(ctx)
+	if !params.IsExpressExecutor(executor.String()) {
+		return errorsmod.Wrapf(ErrUnauthorizedExecutor, "%s is not an express executor", executor)
+	}
+	if uint64(len(msg.Payload)) > params.MaxPayloadSize {
+		return errorsmod.Wrapf(ErrInvalidMessage, "payload of %d bytes exceeds the limit of %d", len(msg.Payload), params.MaxPayloadSize)
+	}
+
+	// The packet will carry a general message, with token if coin is set.
+	msg.Type = TypeGeneralMessage
+	if !coin.Amount.IsNil() && !coin.IsZero() {
+		msg.Type = TypeGeneralMessageWithToken
+	} else {
+		coin = sdk.Coin{}
+	}
+	if err := k.CheckPaused(ctx, msg); err != nil {
+		return err
+	}
+
+	if msg.MessageID == "" {
+		return errorsmod.Wrap(ErrInvalidMessage, "message id is required")
+	}
+	// The packet may have been delivered first, and executed or stored as failed.
+	replayKey := messageKey(msg, msg.MessageID)
+	if k.HasProcessedMessage(ctx, replayKey) || k.HasFailedMessage(ctx, replayKey) {
+		return errorsmod.Wrapf(ErrDuplicateMessage, "message %s from %s/%s", msg.MessageID, msg.SourceChain, msg.SourceAddress)
+	}
+
+	handler, err := k.GetGeneralMessageHandler(destination, msg.Payload)
+	if err != nil {
+		return err
+	}
+	expressHandler, ok := handler.(ExpressHandler)
+	if !ok || !expressHandler.AllowExpress(ctx, msg.SourceChain, msg.SourceAddress, destination, msg.Payload) {
+		return errorsmod.Wrapf(ErrInvalidMessage, "messages to %s cannot be expressed", destination)
+	}
+
+	id := ExpressKey(msg, destination, coin)
+	if _, found := k.GetExpressClaim(ctx, id); found {
+		return errorsmod.Wrapf(ErrAlreadyExpressed, "message %X from %s/%s", id, msg.SourceChain, msg.SourceAddress)
+	}
+	if err := k.ConsumeRateLimits(ctx, msg, destination, coin); err != nil {
+		return err
+	}
+
+	var execute func(sdk.Context) error
+	if msg.Type == TypeGeneralMessage {
+		execute = func(ctx sdk.Context) error {
+			return handler.HandleGeneralMessage(ctx, msg.SourceChain, msg.SourceAddress, destination, msg.Payload)
+		}
+	} else {
+		destAddr, err := sdk.AccAddressFromBech32(destination)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "invalid destination address: %s", err)
+		}
+		if err := k.bankKeeper.SendCoins(ctx, executor, destAddr, sdk.NewCoins(coin)); err != nil {
+			return errorsmod.Wrap(err, "cannot fund express execution")
+		}
+
+		execute = func(ctx sdk.Context) error {
+			return handler.HandleGeneralMessageWithToken(ctx, msg.SourceChain, msg.SourceAddress, destination, msg.Payload, coin)
+		}
+	}
+
+	if _, err := k.ExecuteHandler(ctx, params.MaxHandlerGas, execute); err != nil {
+		return err
+	}
+
+	k.SetExpressClaim(ctx, ExpressClaim{
+		Id:                 id,
+		Executor:           executor.String(),
+		SourceChain:        msg.SourceChain,
+		SourceAddress:      msg.SourceAddress,
+		DestinationAddress: destination,
+		Coin:               coin,
+		ReplayKey:          replayKey,
+		ExpiryHeight:       uint64(ctx.BlockHeight()) + params.ExpressClaimRetentionBlocks,
+	})
+
+	var denom, amount string
+	if !coin.Amount.IsNil() {
+		denom, amount = coin.Denom, coin.Amount.String()
+	}
+
+	k.SetMessageRecord(ctx, newMessageRecord(ctx, id, msg, inboundEvent{
+		destination: destination,
+		denom:       denom,
+		amount:      amount,
+	}, MessageStatusExpressExecuted, nil))
+
+	k.EmitEvent(ctx, &EventMessageExpressed{
+		Executor:      executor.String(),
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Destination:   destination,
+		PayloadHash:   PayloadHash(msg.Payload),
+		MessageId:     msg.MessageID,
+		Denom:         denom,
+		Amount:        amount,
+	})
+
+	return nil
+}
+
+// SettleExpressClaim completes an expressed message whose packet arrived: the claimed coin,
+// which the transfer app credited to the packet receiver, is paid to the executor and the
+// claim is deleted together with its message record. It returns the reimbursed coin.
+func (k Keeper) SettleExpressClaim(ctx sdk.Context, claim ExpressClaim) (sdk.Coin, error) {
+	var reimbursement sdk.Coin
+	if !claim.Coin.Amount.IsNil() && claim.Coin.IsPositive() {
+		receiverAddr, err := sdk.AccAddressFromBech32(claim.DestinationAddress)
+		if err != nil {
+			return sdk.Coin{}, errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+		}
+		executor, err := sdk.AccAddressFromBech32(claim.Executor)
+		if err != nil {
+			return sdk.Coin{}, errorsmod.Wrapf(ErrInvalidMessage, "invalid executor: %s", err)
+		}
+		if err := k.bankKeeper.SendCoins(ctx, receiverAddr, executor, sdk.NewCoins(claim.Coin)); err != nil {
+			return sdk.Coin{}, errorsmod.Wrap(err, "cannot reimburse express executor")
+		}
+		reimbursement = claim.Coin
+	}
+
+	k.deleteExpressClaim(ctx, claim)
+	k.deleteMessageRecord(ctx, claim.Id)
+	return reimbursement, nil
+}
+
+// PruneExpiredExpressClaims deletes the express claims that expired at or before the current
+// height, up to the replay prune limit per block. Their messages are recorded as processed, so
+// a packet arriving after the claim expired does not run the handler a second time.
+func (k Keeper) PruneExpiredExpressClaims(ctx sdk.Context) {
+	limit := k.GetParams(ctx).ReplayPruneLimit
+	end := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()) + 1)
+
+	expiryStore := k.expressExpiryStore(ctx)
+	iterator := expiryStore.Iterator(nil, end)
+
+	var expired [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		if uint64(len(expired)) >= limit {
+			break
+		}
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range expired {
+		claim, found := k.GetExpressClaim(ctx, key[8:])
+		if !found {
+			expiryStore.Delete(key)
+			continue
+		}
+
+		k.deleteExpressClaim(ctx, claim)
+		k.deleteMessageRecord(ctx, claim.Id)
+		k.SetProcessedMessage(ctx, claim.ReplayKey)
+		k.Logger(ctx).Info("express claim expired without its packet",
+			"id", hex.EncodeToString(claim.Id),
+			"executor", claim.Executor,
+			"source_chain", claim.SourceChain,
+			"source_address", claim.SourceAddress,
+		)
+	}
+}
+
+// packetExpressKey returns the express key of msg delivered to receiver with token, after fees.
+func packetExpressKey(msg Message, receiver string, token sdk.Coin) []byte {
+	if msg.Type != TypeGeneralMessageWithToken {
+		token = sdk.Coin{}
+	}
+	return ExpressKey(msg, receiver, token)
+}
+
+// GetExpressClaim returns the express claim of the message with the given express key.
+func (k Keeper) GetExpressClaim(ctx sdk.Context, id []byte) (ExpressClaim, bool) {
+	bz := k.expressClaimStore(ctx).Get(id)
+	if bz == nil {
+		return ExpressClaim{}, false
+	}
+
+	var claim ExpressClaim
+	k.cdc.MustUnmarshal(bz, &claim)
+	return claim, true
+}
+
+// SetExpressClaim stores an express claim and indexes its expiry.
+func (k Keeper) SetExpressClaim(ctx sdk.Context, claim ExpressClaim) {
+	k.expressClaimStore(ctx).Set(claim.Id, k.cdc.MustMarshal(&claim))
+	if claim.ExpiryHeight > 0 {
+		k.expressExpiryStore(ctx).Set(processedExpiryKey(claim.ExpiryHeight, claim.Id), []byte{})
+	}
+}
+
+// deleteExpressClaim deletes an express claim and its expiry index entry.
+func (k Keeper) deleteExpressClaim(ctx sdk.Context, claim ExpressClaim) {
+	k.expressClaimStore(ctx).Delete(claim.Id)
+	if claim.ExpiryHeight > 0 {
+		k.expressExpiryStore(ctx).Delete(processedExpiryKey(claim.ExpiryHeight, claim.Id))
+	}
+}
+
+// GetAllExpressClaims returns every express claim.
+func (k Keeper) GetAllExpressClaims(ctx sdk.Context) []ExpressClaim {
+	iterator := k.expressClaimStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var claims []ExpressClaim
+	for ; iterator.Valid(); iterator.Next() {
+		var claim ExpressClaim
+		k.cdc.MustUnmarshal(iterator.Value(), &claim)
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+func (k Keeper) expressClaimStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), ExpressClaimKeyPrefix)
+}
+
+func (k Keeper) expressExpiryStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), ExpressExpiryKeyPrefix)
+}
@@ -0,0 +1,198 @@
+package gmp_middleware
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// expressFixture returns a fixture with a funded express executor and an express handler.
+func expressFixture(t *testing.T) (*testFixture, sdk.AccAddress) {
+	t.Helper()
+
+	f := newTestFixture(t)
+	executor := testAddr("executor")
+	f.setParams(t, func(p *Params) { p.ExpressExecutors = []string{executor.String()} })
+	f.bank.mint(executor, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 1000)))
+	f.handler.express = true
+	return f, executor
+}
+
+func TestExpressExecuteSettledByPacket(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+	coin := sdk.NewInt64Coin(testDenom(), 100)
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), coin))
+	require.Equal(t, []sdk.Coin{coin}, f.handler.calls)
+	require.Equal(t, sdk.NewCoins(coin), f.bank.balance(receiver))
+	require.Len(t, f.keeper.GetAllExpressClaims(f.ctx), 1)
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Len(t, f.handler.calls, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 1000)), f.bank.balance(executor))
+	require.Equal(t, sdk.NewCoins(coin), f.bank.balance(receiver))
+	require.Empty(t, f.keeper.GetAllExpressClaims(f.ctx))
+
+	record, found := f.keeper.GetMessageRecordByPacket(f.ctx, testChannel, f.sequence)
+	require.True(t, found)
+	require.Equal(t, MessageStatusExpressReimbursed, record.Status)
+	require.Len(t, f.keeper.GetAllMessageRecords(f.ctx), 1)
+}
+
+func TestExpressExecuteMatchesTokenAfterFee(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), sdk.NewInt64Coin(testDenom(), 70)))
+
+	msg := testMessage()
+	msg.Fee = &Fee{Amount: "30", Recipient: testAddr("fee recipient").String()}
+	ack := f.recvPacket(t, receiver.String(), 100, msg)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Len(t, f.handler.calls, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 1000)), f.bank.balance(executor))
+}
+
+func TestExpressExecuteRequiresOptIn(t *testing.T) {
+	f, executor := expressFixture(t)
+	f.handler.express = false
+
+	err := f.keeper.ExpressExecute(f.ctx, executor, testMessage(), testAddr("receiver").String(), sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrInvalidMessage)
+	require.Empty(t, f.handler.calls)
+	require.Empty(t, f.keeper.GetAllExpressClaims(f.ctx))
+}
+
+func TestExpressExecuteRequiresMessageID(t *testing.T) {
+	f, executor := expressFixture(t)
+
+	err := f.keeper.ExpressExecute(f.ctx, executor, testMessageWithID(""), testAddr("receiver").String(), sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrInvalidMessage)
+	require.Empty(t, f.handler.calls)
+	require.Empty(t, f.keeper.GetAllExpressClaims(f.ctx))
+}
+
+func TestExpressExecuteRequiresExecutor(t *testing.T) {
+	f, _ := expressFixture(t)
+	someone := testAddr("someone")
+	f.bank.mint(someone, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)))
+
+	err := f.keeper.ExpressExecute(f.ctx, someone, testMessage(), testAddr("receiver").String(), sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrUnauthorizedExecutor)
+}
+
+func TestExpressExecuteRejectsDuplicateClaim(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver").String()
+	coin := sdk.NewInt64Coin(testDenom(), 100)
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver, coin))
+	require.ErrorIs(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver, coin), ErrAlreadyExpressed)
+	require.Len(t, f.handler.calls, 1)
+}
+
+func TestExpressExecuteRejectsDeliveredMessage(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+	coin := sdk.NewInt64Coin(testDenom(), 100)
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	err := f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), coin)
+	require.ErrorIs(t, err, ErrDuplicateMessage)
+
+	// a message stored as failed was delivered as well
+	f.storeFailedMessage(t, receiver, 100)
+	err = f.keeper.ExpressExecute(f.ctx, executor, testMessageWithID(fmt.Sprintf("0xabc-%d", f.sequence)), receiver.String(), coin)
+	require.ErrorIs(t, err, ErrDuplicateMessage)
+
+	require.Len(t, f.handler.calls, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 1000)), f.bank.balance(executor))
+	require.Empty(t, f.keeper.GetAllExpressClaims(f.ctx))
+}
+
+func TestPruneExpiredExpressClaims(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+	f.setParams(t, func(p *Params) { p.ExpressClaimRetentionBlocks = 10 })
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), sdk.NewInt64Coin(testDenom(), 100)))
+
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 9)
+	f.keeper.PruneExpiredExpressClaims(f.ctx)
+	require.Len(t, f.keeper.GetAllExpressClaims(f.ctx), 1)
+
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	f.keeper.PruneExpiredExpressClaims(f.ctx)
+	require.Empty(t, f.keeper.GetAllExpressClaims(f.ctx))
+	require.Empty(t, f.keeper.GetAllMessageRecords(f.ctx))
+
+	// the late packet does not run the handler again, and the executor is not repaid
+	require.False(t, f.recvPacket(t, receiver.String(), 100, testMessage()).Success())
+	require.Len(t, f.handler.calls, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 900)), f.bank.balance(executor))
+}
+
+func TestExpressClaimNotSettledByOtherPacket(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), sdk.NewInt64Coin(testDenom(), 90)))
+
+	// a packet delivering a different amount is not the expressed message
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Len(t, f.handler.calls, 2)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 910)), f.bank.balance(executor))
+	require.Len(t, f.keeper.GetAllExpressClaims(f.ctx), 1)
+}
+
+func TestExpressExecuteChecksMessageTypePause(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver").String()
+	f.keeper.SetPause(f.ctx, Pause{Scope: PauseScopeMessageType, MessageType: TypeGeneralMessageWithToken})
+
+	err := f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver, sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrPaused)
+	require.Empty(t, f.handler.calls)
+
+	// messages without token are not covered by the pause
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver, sdk.Coin{}))
+	require.Equal(t, []sdk.Coin{{}}, f.handler.calls)
+}
+
+func TestExpressExecuteConsumesRateLimitsOnce(t *testing.T) {
+	f, executor := expressFixture(t)
+	receiver := testAddr("receiver")
+	f.keeper.SetRateLimit(f.ctx, RateLimit{
+		SourceChain: testSourceChain,
+		MaxMessages: 1,
+		Denom:       testDenom(),
+		MaxAmount:   sdkmath.NewInt(100),
+		Window:      time.Hour,
+	})
+
+	require.NoError(t, f.keeper.ExpressExecute(f.ctx, executor, testMessage(), receiver.String(), sdk.NewInt64Coin(testDenom(), 100)))
+	usage, found := f.keeper.GetRateLimitUsage(f.ctx, testSourceChain, "", "")
+	require.True(t, found)
+	require.Equal(t, uint64(1), usage.Messages)
+	require.Equal(t, sdkmath.NewInt(100), usage.Amount)
+
+	// the quota is used up, so only the settling packet gets through
+	err := f.keeper.ExpressExecute(f.ctx, executor, testMessage(), testAddr("other").String(), sdk.NewInt64Coin(testDenom(), 100))
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	usage, _ = f.keeper.GetRateLimitUsage(f.ctx, testSourceChain, "", "")
+	require.Equal(t, uint64(1), usage.Messages)
+
+	require.False(t, f.recvPacket(t, receiver.String(), 100, testMessage()).Success())
+}
@@ -0,0 +1,275 @@
+// Store and retry: with the FAILURE_POLICY_STORE_AND_RETRY policy a message whose handler
+// failed is acknowledged successfully and stored under its replay key, with its tokens moved
+// from the receiver into the gmp module account. Anyone can re-execute it with
+// MsgRetryGeneralMessage, for example once the destination contract was fixed. A message that
+// is not retried within failed_message_retention_blocks is refunded: its tokens are sent back
+// to the source address through Axelar at the end of the block, up to failed_refund_limit
+// messages per block.
+
+package gmp_middleware
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StoreFailedMessage moves coin, if set, from receiver into module escrow and stores msg for
+// retry with the error of its handler.
+func (k Keeper) StoreFailedMessage(ctx sdk.Context, id []byte, msg Message, receiver string, coin *sdk.Coin, handlerErr error) error {
+	fm := FailedMessage{
+		Id:                 id,
+		SourceChain:        msg.SourceChain,
+		SourceAddress:      msg.SourceAddress,
+		Destination:        receiver,
+		DestinationAddress: msg.DestinationAddress,
+		Payload:            msg.Payload,
+		MessageType:        msg.Type,
+		Error:              handlerErr.Error(),
+	}
+	if msg.Next != nil {
+		next, err := json.Marshal(msg.Next)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "cannot encode next hop: %s", err)
+		}
+		fm.Next = next
+	}
+
+	if coin != nil && coin.IsPositive() {
+		receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+		}
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, receiverAddr, ModuleName, sdk.NewCoins(*coin)); err != nil {
+			return err
+		}
+		fm.Coin = *coin
+	}
+
+	if retention := k.GetParams(ctx).FailedMessageRetentionBlocks; retention > 0 {
+		fm.ExpiryHeight = uint64(ctx.BlockHeight()) + retention
+	}
+	k.SetFailedMessage(ctx, fm)
+
+	k.EmitEvent(ctx, &EventMessageStored{
+		Id:            hex.EncodeToString(id),
+		SourceChain:   fm.SourceChain,
+		SourceAddress: fm.SourceAddress,
+		Destination:   fm.Destination,
+		Error:         fm.Error,
+		ExpiryHeight:  fm.ExpiryHeight,
+	})
+	return nil
+}
+
+// RetryFailedMessage re-executes the stored failed message with the given replay key on behalf
+// of sender. The escrowed tokens are handed back to the destination as part of the execution,
+// so a failing retry leaves the message stored as it was.
+func (k Keeper) RetryFailedMessage(ctx sdk.Context, sender sdk.AccAddress, id []byte) error {
+	fm, found := k.GetFailedMessage(ctx, id)
+	if !found {
+		return errorsmod.Wrapf(ErrNoFailedMessage, "message %X", id)
+	}
+
+	msg := Message{
+		SourceChain:        fm.SourceChain,
+		SourceAddress:      fm.SourceAddress,
+		Payload:            fm.Payload,
+		Type:               fm.MessageType,
+		DestinationAddress: fm.DestinationAddress,
+	}
+	if len(fm.Next) > 0 {
+		msg.Next = &NextHop{}
+		if err := json.Unmarshal(fm.Next, msg.Next); err != nil {
+			return errorsmod.Wrapf(ErrInvalidMessage, "cannot decode next hop: %s", err)
+		}
+	}
+	if err := k.CheckPaused(ctx, msg); err != nil {
+		return err
+	}
+
+	var handler GeneralMessageHandler
+	if msg.Type != TypeSendToken {
+		var err error
+		handler, err = k.GetGeneralMessageHandler(fm.Destination, msg.Payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	hasCoin := !fm.Coin.Amount.IsNil() && fm.Coin.IsPositive()
+	execute := func(ctx sdk.Context) error {
+		if hasCoin {
+			destAddr, err := sdk.AccAddressFromBech32(fm.Destination)
+			if err != nil {
+				return errorsmod.Wrapf(ErrInvalidMessage, "invalid destination: %s", err)
+			}
+			if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, destAddr, sdk.NewCoins(fm.Coin)); err != nil {
+				return err
+			}
+		}
+
+		switch msg.Type {
+		case TypeGeneralMessage:
+			return handler.HandleGeneralMessage(ctx, msg.SourceChain, msg.SourceAddress, fm.Destination, msg.Payload)
+		case TypeGeneralMessageWithToken:
+			return handler.HandleGeneralMessageWithToken(ctx, msg.SourceChain, msg.SourceAddress, fm.Destination, msg.Payload, fm.Coin)
+		case TypeSendToken:
+			return k.DeliverToken(ctx, msg, fm.Destination, fm.Coin)
+		default:
+			return errorsmod.Wrapf(ErrInvalidMessage, "unrecognized message type: %d", msg.Type)
+		}
+	}
+	if hasCoin {
+		execute = k.chainNextHop(execute, msg, fm.Destination, fm.Coin)
+	}
+
+	start := time.Now()
+	gasUsed, err := k.ExecuteHandler(ctx, k.GetParams(ctx).MaxHandlerGas, execute)
+	if err != nil {
+		return err
+	}
+
+	k.deleteFailedMessage(ctx, fm)
+	k.setMessageStatus(ctx, id, MessageStatusExecuted, nil)
+
+	k.EmitEvent(ctx, &EventMessageRetried{
+		Id:            hex.EncodeToString(id),
+		SourceChain:   fm.SourceChain,
+		SourceAddress: fm.SourceAddress,
+		Destination:   fm.Destination,
+		Sender:        sender.String(),
+	})
+	recordHandlerExecution(ctx, msg.SourceChain, start, gasUsed)
+	recordInboundMessage(ctx, msg.SourceChain, msg.Type, outcomeExecuted)
+	if hasCoin {
+		recordTokenVolume(ctx, directionInbound, fm.Coin.Denom, fm.Coin.Amount)
+	}
+	return nil
+}
+
+// RefundExpiredFailedMessages sends the tokens of failed messages that expired at or before
+// the current height back to their source address, up to the failed refund limit per block.
+// A refund that cannot be sent is logged and the message stays stored, without expiry, so it
+// can still be retried.
+func (k Keeper) RefundExpiredFailedMessages(ctx sdk.Context) {
+	limit := k.GetParams(ctx).FailedRefundLimit
+	end := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()) + 1)
+
+	expiryStore := k.failedExpiryStore(ctx)
+	iterator := expiryStore.Iterator(nil, end)
+
+	var expired [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		if uint64(len(expired)) >= limit {
+			break
+		}
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range expired {
+		fm, found := k.GetFailedMessage(ctx, key[8:])
+		if !found {
+			expiryStore.Delete(key)
+			continue
+		}
+
+		if err := k.refundFailedMessage(ctx, fm); err != nil {
+			k.Logger(ctx).Error("cannot refund failed gmp message, keeping it for retry",
+				"id", hex.EncodeToString(fm.Id),
+				"source_chain", fm.SourceChain,
+				"source_address", fm.SourceAddress,
+				"error", err,
+			)
+			expiryStore.Delete(key)
+			fm.ExpiryHeight = 0
+			k.SetFailedMessage(ctx, fm)
+		}
+	}
+}
+
+// refundFailedMessage sends the escrowed tokens of fm back to its source address and deletes
+// it. The transfer runs on a branch of ctx so a failed refund leaves no partial state behind.
+// The message is only marked refunded once the refund packet is acknowledged.
+func (k Keeper) refundFailedMessage(ctx sdk.Context, fm FailedMessage) error {
+	if fm.Coin.Amount.IsNil() || !fm.Coin.IsPositive() {
+		k.deleteFailedMessage(ctx, fm)
+		return nil
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	if _, err := k.RefundToSource(cacheCtx, Refund{
+		Id:            fm.Id,
+		SourceChain:   fm.SourceChain,
+		SourceAddress: fm.SourceAddress,
+		Coin:          fm.Coin,
+	}); err != nil {
+		return err
+	}
+	writeCache()
+
+	k.deleteFailedMessage(ctx, fm)
+	return nil
+}
+
+// HasFailedMessage reports whether a failed message is stored under the given replay key.
+func (k Keeper) HasFailedMessage(ctx sdk.Context, id []byte) bool {
+	return k.failedMessageStore(ctx).Has(id)
+}
+
+// GetFailedMessage returns the failed message stored under the given replay key.
+func (k Keeper) GetFailedMessage(ctx sdk.Context, id []byte) (FailedMessage, bool) {
+	bz := k.failedMessageStore(ctx).Get(id)
+	if bz == nil {
+		return FailedMessage{}, false
+	}
+
+	var fm FailedMessage
+	k.cdc.MustUnmarshal(bz, &fm)
+	return fm, true
+}
+
+// SetFailedMessage stores a failed message and indexes its expiry.
+func (k Keeper) SetFailedMessage(ctx sdk.Context, fm FailedMessage) {
+	k.failedMessageStore(ctx).Set(fm.Id, k.cdc.MustMarshal(&fm))
+	if fm.ExpiryHeight > 0 {
+		k.failedExpiryStore(ctx).Set(processedExpiryKey(fm.ExpiryHeight, fm.Id), []byte{})
+	}
+}
+
+// deleteFailedMessage deletes a failed message and its expiry index entry.
+func (k Keeper) deleteFailedMessage(ctx sdk.Context, fm FailedMessage) {
+	k.failedMessageStore(ctx).Delete(fm.Id)
+	if fm.ExpiryHeight > 0 {
+		k.failedExpiryStore(ctx).Delete(processedExpiryKey(fm.ExpiryHeight, fm.Id))
+	}
+}
+
+// GetAllFailedMessages returns every stored failed message.
+func (k Keeper) GetAllFailedMessages(ctx sdk.Context) []FailedMessage {
+	iterator := k.failedMessageStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var messages []FailedMessage
+	for ; iterator.Valid(); iterator.Next() {
+		var fm FailedMessage
+		k.cdc.MustUnmarshal(iterator.Value(), &fm)
+		messages = append(messages, fm)
+	}
+	return messages
+}
+
+func (k Keeper) failedMessageStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), FailedMessageKeyPrefix)
+}
+
+func (k Keeper) failedExpiryStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), FailedExpiryKeyPrefix)
+}
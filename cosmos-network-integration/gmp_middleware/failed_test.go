@@ -0,0 +1,108 @@
+package gmp_middleware
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// storeFailedMessage delivers a failing message under the store and retry policy and returns
+// its replay key. Each call delivers a message with a new message ID.
+func (f *testFixture) storeFailedMessage(t *testing.T, receiver sdk.AccAddress, amount int64) []byte {
+	t.Helper()
+
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyStoreAndRetry })
+	f.handler.err = errors.New("boom")
+	defer func() { f.handler.err = nil }()
+
+	ack := f.recvPacket(t, receiver.String(), amount, testMessageWithID(fmt.Sprintf("0xabc-%d", f.sequence+1)))
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+
+	record, found := f.keeper.GetMessageRecordByPacket(f.ctx, testChannel, f.sequence)
+	require.True(t, found)
+	require.Equal(t, MessageStatusFailedStored, record.Status)
+	return record.Id
+}
+
+func TestRetryFailedMessage(t *testing.T) {
+	f := newTestFixture(t)
+	server := NewMsgServerImpl(f.keeper)
+	receiver := testAddr("receiver")
+	coins := sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100))
+
+	id := f.storeFailedMessage(t, receiver, 100)
+	require.True(t, f.keeper.HasFailedMessage(f.ctx, id))
+	require.Equal(t, coins, f.bank.balance(testModuleAddr))
+	require.Empty(t, f.bank.balance(receiver))
+
+	_, err := server.RetryGeneralMessage(f.ctx, &MsgRetryGeneralMessage{Sender: testAddr("anyone").String(), Id: id})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Coin{coins[0]}, f.handler.calls)
+	require.Equal(t, coins, f.bank.balance(receiver))
+	require.True(t, f.bank.balance(testModuleAddr).IsZero())
+	require.False(t, f.keeper.HasFailedMessage(f.ctx, id))
+
+	record, found := f.keeper.GetMessageRecord(f.ctx, id)
+	require.True(t, found)
+	require.Equal(t, MessageStatusExecuted, record.Status)
+
+	_, err = server.RetryGeneralMessage(f.ctx, &MsgRetryGeneralMessage{Sender: testAddr("anyone").String(), Id: id})
+	require.ErrorIs(t, err, ErrNoFailedMessage)
+}
+
+func TestRefundExpiredFailedMessages(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) {
+		p.FailedMessageRetentionBlocks = 1
+		p.FailedRefundLimit = 1
+	})
+	first := f.storeFailedMessage(t, testAddr("first"), 100)
+	second := f.storeFailedMessage(t, testAddr("second"), 50)
+
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	f.keeper.RefundExpiredFailedMessages(f.ctx)
+	require.Len(t, f.transfer.transfers, 1)
+	require.Len(t, f.keeper.GetAllFailedMessages(f.ctx), 1)
+
+	// the message is refunding until its refund packet is acknowledged
+	refunded := first
+	if f.keeper.HasFailedMessage(f.ctx, first) {
+		refunded = second
+	}
+	record, _ := f.keeper.GetMessageRecord(f.ctx, refunded)
+	require.Equal(t, MessageStatusRefunding, record.Status)
+
+	f.keeper.OnAcknowledgementPacket(f.ctx, f.refundPacket(), channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement())
+	record, _ = f.keeper.GetMessageRecord(f.ctx, refunded)
+	require.Equal(t, MessageStatusRefunded, record.Status)
+	refund, found := f.keeper.GetRefund(f.ctx, refunded)
+	require.True(t, found)
+	require.Equal(t, RefundStatusCompleted, refund.Status)
+
+	// the other message is refunded in the next block
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	f.keeper.RefundExpiredFailedMessages(f.ctx)
+	require.Len(t, f.transfer.transfers, 2)
+	require.Empty(t, f.keeper.GetAllFailedMessages(f.ctx))
+	require.True(t, f.bank.balance(testModuleAddr).IsZero())
+}
+
+func TestFailedRefundKeepsMessageRefunding(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailedMessageRetentionBlocks = 1 })
+	id := f.storeFailedMessage(t, testAddr("receiver"), 100)
+
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	f.keeper.RefundExpiredFailedMessages(f.ctx)
+	f.keeper.OnAcknowledgementPacket(f.ctx, f.refundPacket(), channeltypes.NewErrorAcknowledgement(errors.New("rejected")).Acknowledgement())
+
+	record, _ := f.keeper.GetMessageRecord(f.ctx, id)
+	require.Equal(t, MessageStatusRefunding, record.Status)
+	refund, _ := f.keeper.GetRefund(f.ctx, id)
+	require.Equal(t, RefundStatusFailed, refund.Status)
+}
@@ -0,0 +1,55 @@
+// Inbound fees: the memo of an inbound message may carry a fee, e.g. for the executor or relayer
+// that delivered it. The fee is paid out of the transferred token by the receiver, which was
+// just credited by the transfer app, and only the remaining amount is handed to the handler.
+
+package gmp_middleware
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Validate ensures that the fee is well formed and does not exceed the token it is paid from,
+// and returns the fee amount.
+func (f Fee) Validate(token sdk.Coin) (sdkmath.Int, error) {
+	if f.Recipient == "" {
+		return sdkmath.Int{}, errorsmod.Wrap(ErrInvalidMessage, "fee recipient is required")
+	}
+	amount, ok := sdkmath.NewIntFromString(f.Amount)
+	if !ok || amount.IsNegative() {
+		return sdkmath.Int{}, errorsmod.Wrapf(ErrInvalidMessage, "invalid fee amount %q", f.Amount)
+	}
+	if amount.GT(token.Amount) {
+		return sdkmath.Int{}, errorsmod.Wrapf(ErrInvalidMessage, "fee %s exceeds transferred amount %s", amount, token.Amount)
+	}
+	return amount, nil
+}
+
+// PayFee pays fee out of token from receiver to the fee recipient and returns what is left of token.
+func (k Keeper) PayFee(ctx sdk.Context, receiver string, token sdk.Coin, fee Fee) (sdk.Coin, error) {
+	amount, err := fee.Validate(token)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	if amount.IsZero() {
+		return token, nil
+	}
+
+	receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+	if err != nil {
+		return sdk.Coin{}, errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+	}
+	recipientAddr, err := sdk.AccAddressFromBech32(fee.Recipient)
+	if err != nil {
+		return sdk.Coin{}, errorsmod.Wrapf(ErrInvalidMessage, "invalid fee recipient: %s", err)
+	}
+
+	feeCoin := sdk.NewCoin(token.Denom, amount)
+	if err := k.bankKeeper.SendCoins(ctx, receiverAddr, recipientAddr, sdk.NewCoins(feeCoin)); err != nil {
+		return sdk.Coin{}, errorsmod.Wrap(err, "cannot pay gmp fee")
+	}
+
+	return token.Sub(feeCoin), nil
+}
@@ -0,0 +1,50 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestOnRecvPacketPaysFee(t *testing.T) {
+	f := newTestFixture(t)
+	receiver, relayer := testAddr("receiver"), testAddr("fee recipient")
+	msg := testMessage()
+	msg.Fee = &Fee{Amount: "30", Recipient: relayer.String()}
+
+	ack := f.recvPacket(t, receiver.String(), 100, msg)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Equal(t, []sdk.Coin{sdk.NewInt64Coin(testDenom(), 70)}, f.handler.calls)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 30)), f.bank.balance(relayer))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 70)), f.bank.balance(receiver))
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventFeePaid"))
+}
+
+func TestOnRecvPacketRejectsExcessiveFee(t *testing.T) {
+	f := newTestFixture(t)
+	msg := testMessage()
+	msg.Fee = &Fee{Amount: "101", Recipient: testAddr("fee recipient").String()}
+
+	require.False(t, f.recvPacket(t, testAddr("receiver").String(), 100, msg).Success())
+	require.Empty(t, f.handler.calls)
+}
+
+func TestFeeValidate(t *testing.T) {
+	token := sdk.NewInt64Coin("stake", 10)
+
+	amount, err := Fee{Amount: "10", Recipient: "cosmos1recipient"}.Validate(token)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), amount.Int64())
+
+	for _, fee := range []Fee{
+		{Amount: "1"},
+		{Amount: "-1", Recipient: "cosmos1recipient"},
+		{Amount: "abc", Recipient: "cosmos1recipient"},
+		{Amount: "11", Recipient: "cosmos1recipient"},
+	} {
+		_, err := fee.Validate(token)
+		require.ErrorIs(t, err, ErrInvalidMessage, fee)
+	}
+}
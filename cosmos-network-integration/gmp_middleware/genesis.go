@@ -0,0 +1,267 @@
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+// DefaultGenesisState returns the default genesis state of the gmp module.
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// Validate performs basic genesis state validation.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(gs.ProcessedMessages))
+	for _, pm := range gs.ProcessedMessages {
+		if len(pm.Id) != sha256.Size {
+			return fmt.Errorf("invalid processed message id %X", pm.Id)
+		}
+		if seen[string(pm.Id)] {
+			return fmt.Errorf("duplicate processed message id %X", pm.Id)
+		}
+		seen[string(pm.Id)] = true
+	}
+
+	for _, recovery := range gs.Recoveries {
+		if _, err := sdk.AccAddressFromBech32(recovery.RecoveryAddress); err != nil {
+			return fmt.Errorf("invalid recovery address %q: %w", recovery.RecoveryAddress, err)
+		}
+		if err := recovery.Coins.Validate(); err != nil {
+			return fmt.Errorf("invalid recovery coins for %s: %w", recovery.RecoveryAddress, err)
+		}
+	}
+
+	seenClaims := make(map[string]bool, len(gs.ExpressClaims))
+	for _, claim := range gs.ExpressClaims {
+		if len(claim.Id) != sha256.Size {
+			return fmt.Errorf("invalid express claim id %X", claim.Id)
+		}
+		if seenClaims[string(claim.Id)] {
+			return fmt.Errorf("duplicate express claim id %X", claim.Id)
+		}
+		seenClaims[string(claim.Id)] = true
+
+		if _, err := sdk.AccAddressFromBech32(claim.Executor); err != nil {
+			return fmt.Errorf("invalid express executor %q: %w", claim.Executor, err)
+		}
+		if !claim.Coin.Amount.IsNil() && !claim.Coin.IsValid() {
+			return fmt.Errorf("invalid express claim coin %s", claim.Coin)
+		}
+		if len(claim.ReplayKey) != sha256.Size {
+			return fmt.Errorf("invalid replay key %X of express claim %X", claim.ReplayKey, claim.Id)
+		}
+	}
+
+	for _, pause := range gs.Pauses {
+		if err := pause.Validate(); err != nil {
+			return err
+		}
+	}
+
+	seenLimits := make(map[string]bool, len(gs.RateLimits))
+	for _, rl := range gs.RateLimits {
+		if err := rl.Validate(); err != nil {
+			return err
+		}
+		key := string(rateLimitKey(rl.SourceChain, rl.SourceAddress, rl.Destination))
+		if seenLimits[key] {
+			return fmt.Errorf("duplicate rate limit for %s", rl.describe())
+		}
+		seenLimits[key] = true
+	}
+
+	for _, usage := range gs.RateLimitUsages {
+		if !seenLimits[string(rateLimitKey(usage.SourceChain, usage.SourceAddress, usage.Destination))] {
+			return fmt.Errorf("usage of unknown rate limit for source %s/%s to %s", usage.SourceChain, usage.SourceAddress, usage.Destination)
+		}
+	}
+
+	seenRecords := make(map[string]bool, len(gs.MessageRecords))
+	for _, record := range gs.MessageRecords {
+		if len(record.Id) != sha256.Size {
+			return fmt.Errorf("invalid message record id %X", record.Id)
+		}
+		if seenRecords[string(record.Id)] {
+			return fmt.Errorf("duplicate message record id %X", record.Id)
+		}
+		seenRecords[string(record.Id)] = true
+
+		if len(record.PayloadHash) != sha256.Size {
+			return fmt.Errorf("invalid payload hash %X of message record %X", record.PayloadHash, record.Id)
+		}
+		if _, ok := MessageStatus_name[int32(record.Status)]; !ok || record.Status == MessageStatusUnspecified {
+			return fmt.Errorf("invalid status %d of message record %X", record.Status, record.Id)
+		}
+	}
+
+	seenFailed := make(map[string]bool, len(gs.FailedMessages))
+	for _, fm := range gs.FailedMessages {
+		if len(fm.Id) != sha256.Size {
+			return fmt.Errorf("invalid failed message id %X", fm.Id)
+		}
+		if seenFailed[string(fm.Id)] {
+			return fmt.Errorf("duplicate failed message id %X", fm.Id)
+		}
+		seenFailed[string(fm.Id)] = true
+
+		if _, err := sdk.AccAddressFromBech32(fm.Destination); err != nil {
+			return fmt.Errorf("invalid destination %q of failed message %X: %w", fm.Destination, fm.Id, err)
+		}
+		if !fm.Coin.Amount.IsNil() && !fm.Coin.IsValid() {
+			return fmt.Errorf("invalid coin %s of failed message %X", fm.Coin, fm.Id)
+		}
+	}
+
+	seenRefunds := make(map[string]bool, len(gs.Refunds))
+	for _, refund := range gs.Refunds {
+		if len(refund.Id) != sha256.Size {
+			return fmt.Errorf("invalid refund id %X", refund.Id)
+		}
+		if seenRefunds[string(refund.Id)] {
+			return fmt.Errorf("duplicate refund id %X", refund.Id)
+		}
+		seenRefunds[string(refund.Id)] = true
+
+		if !refund.Coin.IsValid() || !refund.Coin.IsPositive() {
+			return fmt.Errorf("invalid coin %s of refund %X", refund.Coin, refund.Id)
+		}
+		if _, ok := RefundStatus_name[int32(refund.Status)]; !ok || refund.Status == RefundStatusUnspecified {
+			return fmt.Errorf("invalid status %d of refund %X", refund.Status, refund.Id)
+		}
+	}
+
+	seenSymbols := make(map[string]bool, len(gs.Assets))
+	seenDenoms := make(map[string]bool, len(gs.Assets))
+	for _, asset := range gs.Assets {
+		if err := asset.Validate(); err != nil {
+			return err
+		}
+		if seenSymbols[asset.Symbol] {
+			return fmt.Errorf("duplicate asset symbol %s", asset.Symbol)
+		}
+		if seenDenoms[asset.Denom] {
+			return fmt.Errorf("duplicate asset denom %s", asset.Denom)
+		}
+		if !gs.Params.IsAxelarChannel(transfertypes.PortID, asset.ChannelId) {
+			return fmt.Errorf("channel %s of asset %s is not an axelar channel", asset.ChannelId, asset.Symbol)
+		}
+		seenSymbols[asset.Symbol] = true
+		seenDenoms[asset.Denom] = true
+	}
+	for _, asset := range gs.Assets {
+		if seenDenoms[asset.Symbol] {
+			return fmt.Errorf("asset symbol %s is the denom of another asset", asset.Symbol)
+		}
+	}
+
+	seenPackets := make(map[string]bool, len(gs.OutboundPackets))
+	for _, packet := range gs.OutboundPackets {
+		if err := host.ChannelIdentifierValidator(packet.ChannelId); err != nil {
+			return fmt.Errorf("invalid channel of outbound packet %s/%d: %w", packet.ChannelId, packet.Sequence, err)
+		}
+		key := string(packetKey(packet.ChannelId, packet.Sequence))
+		if seenPackets[key] {
+			return fmt.Errorf("duplicate outbound packet %s/%d", packet.ChannelId, packet.Sequence)
+		}
+		seenPackets[key] = true
+	}
+
+	seenCallbacks := make(map[string]bool, len(gs.PacketCallbacks))
+	for _, callback := range gs.PacketCallbacks {
+		key := string(packetKey(callback.ChannelId, callback.Sequence))
+		if !seenPackets[key] {
+			return fmt.Errorf("callback of unknown outbound packet %s/%d", callback.ChannelId, callback.Sequence)
+		}
+		if seenCallbacks[key] {
+			return fmt.Errorf("duplicate callback of outbound packet %s/%d", callback.ChannelId, callback.Sequence)
+		}
+		if callback.Module == "" {
+			return fmt.Errorf("callback of outbound packet %s/%d names no module", callback.ChannelId, callback.Sequence)
+		}
+		seenCallbacks[key] = true
+	}
+	return nil
+}
+
+// InitGenesis initializes the gmp module state from a genesis state.
+func (k Keeper) InitGenesis(ctx sdk.Context, gs GenesisState) {
+	if err := k.SetParams(ctx, gs.Params); err != nil {
+		panic(err)
+	}
+
+	for _, pm := range gs.ProcessedMessages {
+		k.setProcessedMessage(ctx, pm)
+	}
+
+	for _, recovery := range gs.Recoveries {
+		k.SetRecovery(ctx, recovery)
+	}
+
+	for _, claim := range gs.ExpressClaims {
+		k.SetExpressClaim(ctx, claim)
+	}
+
+	for _, pause := range gs.Pauses {
+		k.SetPause(ctx, pause)
+	}
+
+	for _, rl := range gs.RateLimits {
+		k.SetRateLimit(ctx, rl)
+	}
+	for _, usage := range gs.RateLimitUsages {
+		k.SetRateLimitUsage(ctx, usage)
+	}
+
+	for _, record := range gs.MessageRecords {
+		k.SetMessageRecord(ctx, record)
+	}
+
+	for _, fm := range gs.FailedMessages {
+		k.SetFailedMessage(ctx, fm)
+	}
+
+	for _, refund := range gs.Refunds {
+		k.SetRefund(ctx, refund)
+	}
+
+	for _, asset := range gs.Assets {
+		k.SetAsset(ctx, asset)
+	}
+
+	for _, packet := range gs.OutboundPackets {
+		k.setOutboundPacket(ctx, packet.ChannelId, packet.Sequence)
+	}
+	for _, callback := range gs.PacketCallbacks {
+		k.packetCallbackStore(ctx).Set(packetKey(callback.ChannelId, callback.Sequence), []byte(callback.Module))
+	}
+}
+
+// ExportGenesis exports the gmp module state.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *GenesisState {
+	return &GenesisState{
+		Params:            k.GetParams(ctx),
+		ProcessedMessages: k.GetAllProcessedMessages(ctx),
+		Recoveries:        k.GetAllRecoveries(ctx),
+		ExpressClaims:     k.GetAllExpressClaims(ctx),
+		Pauses:            k.GetAllPauses(ctx),
+		RateLimits:        k.GetAllRateLimits(ctx),
+		RateLimitUsages:   k.GetAllRateLimitUsages(ctx),
+		MessageRecords:    k.GetAllMessageRecords(ctx),
+		FailedMessages:    k.GetAllFailedMessages(ctx),
+		Refunds:           k.GetAllRefunds(ctx),
+		Assets:            k.GetAllAssets(ctx),
+		OutboundPackets:   k.GetAllOutboundPackets(ctx),
+		PacketCallbacks:   k.GetAllPacketCallbacks(ctx),
+	}
+}
@@ -0,0 +1,288 @@
+package gmp_middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"cosmossdk.io/store/prefix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+var _ QueryServer = Querier{}
+
+// Querier implements the gmp QueryServer interface.
+type Querier struct {
+	*Keeper
+}
+
+// NewQuerier returns a new gmp Querier for the provided Keeper.
+func NewQuerier(k *Keeper) Querier {
+	return Querier{Keeper: k}
+}
+
+// Params returns the gmp module parameters.
+func (q Querier) Params(goCtx context.Context, _ *QueryParamsRequest) (*QueryParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &QueryParamsResponse{Params: q.GetParams(ctx)}, nil
+}
+
+// Pauses returns the active pause switches.
+func (q Querier) Pauses(goCtx context.Context, _ *QueryPausesRequest) (*QueryPausesResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &QueryPausesResponse{Pauses: q.GetAllPauses(ctx)}, nil
+}
+
+// RateLimits returns the GMP rate limits with their usage in the current window.
+func (q Querier) RateLimits(goCtx context.Context, _ *QueryRateLimitsRequest) (*QueryRateLimitsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	limits := q.GetAllRateLimits(ctx)
+	statuses := make([]RateLimitStatus, 0, len(limits))
+	for _, rl := range limits {
+		statuses = append(statuses, RateLimitStatus{RateLimit: rl, Usage: q.currentUsage(ctx, rl)})
+	}
+	return &QueryRateLimitsResponse{RateLimits: statuses}, nil
+}
+
+// Messages lists the inbound message history matching the request filters.
+func (q Querier) Messages(goCtx context.Context, req *QueryMessagesRequest) (*QueryMessagesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var records []MessageRecord
+	pageRes, err := query.FilteredPaginate(q.messageRecordStore(ctx), req.Pagination, func(_, value []byte, accumulate bool) (bool, error) {
+		var record MessageRecord
+		if err := q.cdc.Unmarshal(value, &record); err != nil {
+			return false, err
+		}
+		if !req.matches(record) {
+			return false, nil
+		}
+		if accumulate {
+			records = append(records, record)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &QueryMessagesResponse{Messages: records, Pagination: pageRes}, nil
+}
+
+// matches reports whether record passes the request filters.
+func (req *QueryMessagesRequest) matches(record MessageRecord) bool {
+	switch {
+	case req.SourceChain != "" && record.SourceChain != req.SourceChain:
+		return false
+	case req.SourceAddress != "" && record.SourceAddress != req.SourceAddress:
+		return false
+	case req.Destination != "" && record.Destination != req.Destination:
+		return false
+	case req.Status != MessageStatusUnspecified && record.Status != req.Status:
+		return false
+	case req.MinHeight > 0 && record.Height < req.MinHeight:
+		return false
+	case req.MaxHeight > 0 && record.Height > req.MaxHeight:
+		return false
+	}
+	return true
+}
+
+// Message returns an inbound message by its replay key.
+func (q Querier) Message(goCtx context.Context, req *QueryMessageRequest) (*QueryMessageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	id, err := hex.DecodeString(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid message id: %s", err)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	record, found := q.GetMessageRecord(ctx, id)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "message %s not found", req.Id)
+	}
+
+	return &QueryMessageResponse{Message: record}, nil
+}
+
+// MessageByPacket returns the inbound message delivered by a packet.
+func (q Querier) MessageByPacket(goCtx context.Context, req *QueryMessageByPacketRequest) (*QueryMessageByPacketResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	record, found := q.GetMessageRecordByPacket(ctx, req.ChannelId, req.Sequence)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no message delivered by packet %s/%d", req.ChannelId, req.Sequence)
+	}
+
+	return &QueryMessageByPacketResponse{Message: record}, nil
+}
+
+// FailedMessages lists the failed messages stored for retry.
+func (q Querier) FailedMessages(goCtx context.Context, req *QueryFailedMessagesRequest) (*QueryFailedMessagesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var messages []FailedMessage
+	pageRes, err := query.Paginate(q.failedMessageStore(ctx), req.Pagination, func(_, value []byte) error {
+		var fm FailedMessage
+		if err := q.cdc.Unmarshal(value, &fm); err != nil {
+			return err
+		}
+		messages = append(messages, fm)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &QueryFailedMessagesResponse{FailedMessages: messages, Pagination: pageRes}, nil
+}
+
+// Refunds lists the refunds of failed messages with the requested status.
+func (q Querier) Refunds(goCtx context.Context, req *QueryRefundsRequest) (*QueryRefundsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var refunds []Refund
+	pageRes, err := query.FilteredPaginate(q.refundStore(ctx), req.Pagination, func(_, value []byte, accumulate bool) (bool, error) {
+		var refund Refund
+		if err := q.cdc.Unmarshal(value, &refund); err != nil {
+			return false, err
+		}
+		if req.Status != RefundStatusUnspecified && refund.Status != req.Status {
+			return false, nil
+		}
+		if accumulate {
+			refunds = append(refunds, refund)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &QueryRefundsResponse{Refunds: refunds, Pagination: pageRes}, nil
+}
+
+// Refund returns the refund of a failed message by its replay key.
+func (q Querier) Refund(goCtx context.Context, req *QueryRefundRequest) (*QueryRefundResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	id, err := hex.DecodeString(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid message id: %s", err)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	refund, found := q.GetRefund(ctx, id)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "refund of message %s not found", req.Id)
+	}
+
+	return &QueryRefundResponse{Refund: refund}, nil
+}
+
+// MessagesByPayloadHash lists the inbound messages carrying the payload with the given hash.
+func (q Querier) MessagesByPayloadHash(goCtx context.Context, req *QueryMessagesByPayloadHashRequest) (*QueryMessagesByPayloadHashResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	payloadHash, err := hex.DecodeString(req.PayloadHash)
+	if err != nil || len(payloadHash) != sha256.Size {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid payload hash %q", req.PayloadHash)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(q.messagePayloadIndexStore(ctx), payloadHash)
+
+	var records []MessageRecord
+	pageRes, err := query.Paginate(store, req.Pagination, func(id, _ []byte) error {
+		if record, found := q.GetMessageRecord(ctx, id); found {
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &QueryMessagesByPayloadHashResponse{Messages: records, Pagination: pageRes}, nil
+}
+
+// Assets lists the registered Axelar assets.
+func (q Querier) Assets(goCtx context.Context, req *QueryAssetsRequest) (*QueryAssetsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var assets []Asset
+	pageRes, err := query.Paginate(q.assetStore(ctx), req.Pagination, func(_, value []byte) error {
+		var asset Asset
+		if err := q.cdc.Unmarshal(value, &asset); err != nil {
+			return err
+		}
+		assets = append(assets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &QueryAssetsResponse{Assets: assets, Pagination: pageRes}, nil
+}
+
+// AssetBySymbol returns a registered Axelar asset by its symbol.
+func (q Querier) AssetBySymbol(goCtx context.Context, req *QueryAssetBySymbolRequest) (*QueryAssetBySymbolResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	asset, found := q.GetAsset(ctx, req.Symbol)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "asset %s not registered", req.Symbol)
+	}
+
+	return &QueryAssetBySymbolResponse{Asset: asset}, nil
+}
+
+// AssetByDenom returns a registered Axelar asset by its local denom.
+func (q Querier) AssetByDenom(goCtx context.Context, req *QueryAssetByDenomRequest) (*QueryAssetByDenomResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	asset, found := q.GetAssetByDenom(ctx, req.Denom)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no asset registered with denom %s", req.Denom)
+	}
+
+	return &QueryAssetByDenomResponse{Asset: asset}, nil
+}
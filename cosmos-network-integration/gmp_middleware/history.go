@@ -0,0 +1,129 @@
+// Message history: every inbound message that leaves state behind is recorded with its status,
+// indexed by the packet that delivered it and by its payload hash, so clients can ask whether a
+// message was executed. Packets rejected with an error acknowledgement are reverted by IBC and
+// have no record. Records are pruned together with the replay protection record of the message.
+
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// newMessageRecord returns the record of an inbound message with the given status.
+func newMessageRecord(ctx sdk.Context, id []byte, msg Message, event inboundEvent, status MessageStatus, err error) MessageRecord {
+	payloadHash := sha256.Sum256(msg.Payload)
+	record := MessageRecord{
+		Id:            id,
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Destination:   event.destination,
+		PayloadHash:   payloadHash[:],
+		MessageType:   msg.Type,
+		Denom:         event.denom,
+		Amount:        event.amount,
+		ChannelId:     event.channelID,
+		Sequence:      event.sequence,
+		Height:        ctx.BlockHeight(),
+		Status:        status,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// GetMessageRecord returns the record of the message with the given replay key.
+func (k Keeper) GetMessageRecord(ctx sdk.Context, id []byte) (MessageRecord, bool) {
+	bz := k.messageRecordStore(ctx).Get(id)
+	if bz == nil {
+		return MessageRecord{}, false
+	}
+
+	var record MessageRecord
+	k.cdc.MustUnmarshal(bz, &record)
+	return record, true
+}
+
+// GetMessageRecordByPacket returns the record of the message delivered by the given packet.
+func (k Keeper) GetMessageRecordByPacket(ctx sdk.Context, channelID string, sequence uint64) (MessageRecord, bool) {
+	id := k.messagePacketIndexStore(ctx).Get(packetKey(channelID, sequence))
+	if id == nil {
+		return MessageRecord{}, false
+	}
+	return k.GetMessageRecord(ctx, id)
+}
+
+// SetMessageRecord stores a message record and its indexes.
+func (k Keeper) SetMessageRecord(ctx sdk.Context, record MessageRecord) {
+	k.messageRecordStore(ctx).Set(record.Id, k.cdc.MustMarshal(&record))
+	if record.ChannelId != "" {
+		k.messagePacketIndexStore(ctx).Set(packetKey(record.ChannelId, record.Sequence), record.Id)
+	}
+	k.messagePayloadIndexStore(ctx).Set(messagePayloadKey(record.PayloadHash, record.Id), []byte{})
+}
+
+// setMessageStatus updates the status of the record of the message with the given replay key,
+// if there is one.
+func (k Keeper) setMessageStatus(ctx sdk.Context, id []byte, status MessageStatus, err error) {
+	record, found := k.GetMessageRecord(ctx, id)
+	if !found {
+		return
+	}
+
+	record.Status = status
+	record.Error = ""
+	if err != nil {
+		record.Error = err.Error()
+	}
+	k.messageRecordStore(ctx).Set(id, k.cdc.MustMarshal(&record))
+}
+
+// deleteMessageRecord deletes the record of the message with the given replay key and its indexes.
+func (k Keeper) deleteMessageRecord(ctx sdk.Context, id []byte) {
+	record, found := k.GetMessageRecord(ctx, id)
+	if !found {
+		return
+	}
+
+	k.messageRecordStore(ctx).Delete(id)
+	if record.ChannelId != "" {
+		k.messagePacketIndexStore(ctx).Delete(packetKey(record.ChannelId, record.Sequence))
+	}
+	k.messagePayloadIndexStore(ctx).Delete(messagePayloadKey(record.PayloadHash, id))
+}
+
+// GetAllMessageRecords returns every message record.
+func (k Keeper) GetAllMessageRecords(ctx sdk.Context) []MessageRecord {
+	iterator := k.messageRecordStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var records []MessageRecord
+	for ; iterator.Valid(); iterator.Next() {
+		var record MessageRecord
+		k.cdc.MustUnmarshal(iterator.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+
+func (k Keeper) messageRecordStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), MessageRecordKeyPrefix)
+}
+
+func (k Keeper) messagePacketIndexStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), MessagePacketIndexKeyPrefix)
+}
+
+func (k Keeper) messagePayloadIndexStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), MessagePayloadIndexKeyPrefix)
+}
+
+// messagePayloadKey returns the key of the payload hash index: <payload_hash><id>
+func messagePayloadKey(payloadHash, id []byte) []byte {
+	return append(append([]byte{}, payloadHash...), id...)
+}
@@ -0,0 +1,95 @@
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMessageHistoryQueries(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyStoreAndRetry })
+	querier := NewQuerier(f.keeper)
+	receiver := testAddr("receiver").String()
+
+	require.True(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	executed := f.sequence
+
+	f.handler.err = errors.New("boom")
+	failed := testMessage()
+	failed.Payload = append(failed.Payload, "-failed"...)
+	require.True(t, f.recvPacket(t, testAddr("other").String(), 100, failed).Success())
+
+	res, err := querier.Messages(f.ctx, &QueryMessagesRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 2)
+
+	res, err = querier.Messages(f.ctx, &QueryMessagesRequest{Status: MessageStatusFailedStored})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	require.Contains(t, res.Messages[0].Error, "boom")
+
+	res, err = querier.Messages(f.ctx, &QueryMessagesRequest{Destination: receiver, SourceChain: testSourceChain})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	record := res.Messages[0]
+	require.Equal(t, MessageStatusExecuted, record.Status)
+	require.Equal(t, testChannel, record.ChannelId)
+	require.Equal(t, executed, record.Sequence)
+	require.Equal(t, testDenom(), record.Denom)
+	require.Equal(t, "100", record.Amount)
+
+	byPacket, err := querier.MessageByPacket(f.ctx, &QueryMessageByPacketRequest{ChannelId: testChannel, Sequence: executed})
+	require.NoError(t, err)
+	require.Equal(t, record, byPacket.Message)
+
+	byID, err := querier.Message(f.ctx, &QueryMessageRequest{Id: hex.EncodeToString(record.Id)})
+	require.NoError(t, err)
+	require.Equal(t, record, byID.Message)
+
+	payloadHash := sha256.Sum256(testMessage().Payload)
+	byPayload, err := querier.MessagesByPayloadHash(f.ctx, &QueryMessagesByPayloadHashRequest{PayloadHash: hex.EncodeToString(payloadHash[:])})
+	require.NoError(t, err)
+	require.Equal(t, []MessageRecord{record}, byPayload.Messages)
+
+	res, err = querier.Messages(f.ctx, &QueryMessagesRequest{MinHeight: f.ctx.BlockHeight() + 1})
+	require.NoError(t, err)
+	require.Empty(t, res.Messages)
+}
+
+func TestMessageHistoryQueryErrors(t *testing.T) {
+	f := newTestFixture(t)
+	querier := NewQuerier(f.keeper)
+
+	_, err := querier.Message(f.ctx, &QueryMessageRequest{Id: "not hex"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = querier.Message(f.ctx, &QueryMessageRequest{Id: "00"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = querier.MessageByPacket(f.ctx, &QueryMessageByPacketRequest{ChannelId: testChannel, Sequence: 1})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = querier.MessagesByPayloadHash(f.ctx, &QueryMessagesByPayloadHashRequest{PayloadHash: "00"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMessageHistoryPrunedWithReplayRecords(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.ReplayRetentionBlocks = 1 })
+
+	require.True(t, f.recvPacket(t, testAddr("receiver").String(), 100, testMessage()).Success())
+	_, found := f.keeper.GetMessageRecordByPacket(f.ctx, testChannel, f.sequence)
+	require.True(t, found)
+
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	f.keeper.PruneProcessedMessages(f.ctx)
+	_, found = f.keeper.GetMessageRecordByPacket(f.ctx, testChannel, f.sequence)
+	require.False(t, found)
+	require.Empty(t, f.keeper.GetAllMessageRecords(f.ctx))
+}
@@ -0,0 +1,146 @@
+// The Keeper is the state owner of the gmp module. It holds the module store and the
+// Router that the IBC middleware uses to hand decoded Axelar messages to their handlers.
+
+package gmp_middleware
+
+import (
+	"cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+)
+
+// Keeper manages the state of the gmp module.
+type Keeper struct {
+	cdc          codec.BinaryCodec
+	storeService store.KVStoreService
+
+	// the address capable of executing a MsgUpdateParams message. Typically, this
+	// should be the x/gov module account.
+	authority string
+
+	channelKeeper  ChannelKeeper
+	transferKeeper TransferKeeper
+	bankKeeper     BankKeeper
+	circuitKeeper  CircuitKeeper
+	contractKeeper ContractKeeper
+
+	router    *Router
+	callbacks map[string]GMPCallbacks
+}
+
+// NewKeeper creates a new gmp Keeper instance.
+func NewKeeper(
+	cdc codec.BinaryCodec,
+	storeService store.KVStoreService,
+	authority string,
+	channelKeeper ChannelKeeper,
+	transferKeeper TransferKeeper,
+	bankKeeper BankKeeper,
+	circuitKeeper CircuitKeeper,
+) *Keeper {
+	return &Keeper{
+		cdc:            cdc,
+		storeService:   storeService,
+		authority:      authority,
+		channelKeeper:  channelKeeper,
+		transferKeeper: transferKeeper,
+		bankKeeper:     bankKeeper,
+		circuitKeeper:  circuitKeeper,
+	}
+}
+
+// GetAuthority returns the gmp module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// SetRouter sets the Router that dispatches inbound GMP messages, and seals it.
+// It must be called once during app wiring, before the first block is processed.
+func (k *Keeper) SetRouter(router *Router) {
+	if k.router != nil && k.router.Sealed() {
+		panic("cannot reset a sealed gmp router")
+	}
+	k.router = router
+	k.router.Seal()
+}
+
+// SetContractKeeper sets the keeper that chained deliveries use to execute CosmWasm contracts.
+// Without it, messages that chain into a contract fail.
+func (k *Keeper) SetContractKeeper(contractKeeper ContractKeeper) {
+	k.contractKeeper = contractKeeper
+}
+
+// GetGeneralMessageHandler returns the handler registered for a message sent to destAddress
+// with the given payload.
+func (k Keeper) GetGeneralMessageHandler(destAddress string, payload []byte) (GeneralMessageHandler, error) {
+	if k.router == nil {
+		return nil, errorsmod.Wrap(ErrNoHandler, "gmp router not set")
+	}
+	return k.router.Route(destAddress, payload)
+}
+
+// GetParams returns the current gmp module parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (p Params) {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(ParamsKey)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return p
+	}
+
+	k.cdc.MustUnmarshal(bz, &p)
+	return p
+}
+
+// SetParams sets the gmp module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, p Params) error {
+	bz, err := k.cdc.Marshal(&p)
+	if err != nil {
+		return err
+	}
+
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(ParamsKey, bz)
+}
+
+// IsAxelarGMPAccount reports whether the address is a governance-approved Axelar GMP account.
+func (k Keeper) IsAxelarGMPAccount(ctx sdk.Context, addr string) bool {
+	return k.GetParams(ctx).IsAxelarGMPAccount(addr)
+}
+
+// ValidateAxelarChannel checks that GMP packets may be accepted on the given local channel end.
+// The channel must be configured in params, and its underlying client must be a Tendermint
+// light client tracking the configured Axelar chain ID.
+func (k Keeper) ValidateAxelarChannel(ctx sdk.Context, portID, channelID string) error {
+	params := k.GetParams(ctx)
+	if !params.IsAxelarChannel(portID, channelID) {
+		return errorsmod.Wrapf(ErrUnauthorizedChannel, "%s/%s is not a configured axelar channel", portID, channelID)
+	}
+
+	clientID, clientState, err := k.channelKeeper.GetChannelClientState(ctx, portID, channelID)
+	if err != nil {
+		return errorsmod.Wrapf(ErrUnauthorizedChannel, "cannot resolve client for %s/%s: %s", portID, channelID, err)
+	}
+
+	tmClientState, ok := clientState.(*ibctm.ClientState)
+	if !ok {
+		return errorsmod.Wrapf(ErrUnauthorizedChannel, "client %s is not a tendermint client", clientID)
+	}
+
+	if tmClientState.ChainId != params.AxelarChainId {
+		return errorsmod.Wrapf(ErrUnauthorizedChannel, "client %s tracks chain %s, expected %s", clientID, tmClientState.ChainId, params.AxelarChainId)
+	}
+
+	return nil
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+ModuleName)
+}
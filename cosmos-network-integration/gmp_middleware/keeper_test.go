@@ -0,0 +1,418 @@
+package gmp_middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	circuittypes "cosmossdk.io/x/circuit/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+
+	"axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
+)
+
+const (
+	testAxelarChainID = "axelar-dojo-1"
+	testChannel       = "channel-0"
+	testSourceChain   = "Ethereum"
+	testSourceAddress = "0x5425890298aed601595a70AB815c96711a31Bc65"
+)
+
+var (
+	testAxelarAccount = mustBech32("axelar", []byte("axelar gmp account"))
+	testAuthority     = authtypes.NewModuleAddress("gov")
+	testModuleAddr    = authtypes.NewModuleAddress(ModuleName)
+	// testPayloadVersion routes payloads to the mock handler of the test fixture.
+	testPayloadVersion = []byte{0x00, 0x00, 0x00, 0x09}
+)
+
+func mustBech32(hrp string, bz []byte) string {
+	addr, err := bech32.ConvertAndEncode(hrp, bz)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// testAddr returns a deterministic account address for name.
+func testAddr(name string) sdk.AccAddress {
+	return authtypes.NewModuleAddress("test/" + name)
+}
+
+// mockBankKeeper keeps balances in memory.
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+}
+
+func (b *mockBankKeeper) balance(addr sdk.AccAddress) sdk.Coins {
+	return b.balances[addr.String()]
+}
+
+func (b *mockBankKeeper) mint(addr sdk.AccAddress, coins sdk.Coins) {
+	b.balances[addr.String()] = b.balances[addr.String()].Add(coins...)
+}
+
+func (b *mockBankKeeper) SendCoins(_ context.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	balance, negative := b.balances[fromAddr.String()].SafeSub(amt...)
+	if negative {
+		return fmt.Errorf("insufficient funds: %s < %s", b.balances[fromAddr.String()], amt)
+	}
+	b.balances[fromAddr.String()] = balance
+	b.mint(toAddr, amt)
+	return nil
+}
+
+func (b *mockBankKeeper) SendCoinsFromAccountToModule(ctx context.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	return b.SendCoins(ctx, senderAddr, authtypes.NewModuleAddress(recipientModule), amt)
+}
+
+func (b *mockBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	return b.SendCoins(ctx, authtypes.NewModuleAddress(senderModule), recipientAddr, amt)
+}
+
+func (b *mockBankKeeper) GetBalance(_ context.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.Coin{Denom: denom, Amount: b.balances[addr.String()].AmountOf(denom)}
+}
+
+func (b *mockBankKeeper) HasSupply(_ context.Context, denom string) bool {
+	for _, balance := range b.balances {
+		if balance.AmountOf(denom).IsPositive() {
+			return true
+		}
+	}
+	return false
+}
+
+// mockTransferKeeper records the transfers it is asked to send and escrows their tokens.
+type mockTransferKeeper struct {
+	bank      *mockBankKeeper
+	transfers []*transfertypes.MsgTransfer
+}
+
+func (t *mockTransferKeeper) Transfer(ctx context.Context, msg *transfertypes.MsgTransfer) (*transfertypes.MsgTransferResponse, error) {
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+	if sender.Equals(testModuleAddr) {
+		return nil, fmt.Errorf("%s is not allowed to send funds", sender)
+	}
+	escrow := transfertypes.GetEscrowAddress(msg.SourcePort, msg.SourceChannel)
+	if err := t.bank.SendCoins(ctx, sender, escrow, sdk.NewCoins(msg.Token)); err != nil {
+		return nil, err
+	}
+
+	t.transfers = append(t.transfers, msg)
+	return &transfertypes.MsgTransferResponse{Sequence: uint64(len(t.transfers))}, nil
+}
+
+// mockChannelKeeper resolves every channel to a tendermint client of chainID.
+type mockChannelKeeper struct {
+	chainID string
+}
+
+func (c mockChannelKeeper) GetChannelClientState(_ sdk.Context, _, channelID string) (string, ibcexported.ClientState, error) {
+	return "07-tendermint-0", &ibctm.ClientState{ChainId: c.chainID}, nil
+}
+
+// mockCircuitKeeper grants circuit permissions from a map.
+type mockCircuitKeeper struct {
+	permissions map[string]circuittypes.Permissions
+}
+
+func (c mockCircuitKeeper) GetPermissions(_ context.Context, address []byte) (circuittypes.Permissions, error) {
+	return c.permissions[sdk.AccAddress(address).String()], nil
+}
+
+// mockContractKeeper records the contract executions it is asked to run.
+type mockContractKeeper struct {
+	bank  *mockBankKeeper
+	calls []string
+	err   error
+}
+
+func (c *mockContractKeeper) Execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+	if err := c.bank.SendCoins(ctx, caller, contractAddress, coins); err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.calls = append(c.calls, string(msg))
+	return nil, nil
+}
+
+// mockHandler records the messages it handles, and fails them if err is set.
+type mockHandler struct {
+	calls []sdk.Coin
+	err   error
+	// gas is consumed on every call.
+	gas uint64
+	// express allows express execution.
+	express bool
+	// spend, if set, is called with the receiver and coin of every call with token.
+	spend func(receiver string, coin sdk.Coin) error
+}
+
+func (h *mockHandler) AllowExpress(sdk.Context, string, string, string, []byte) bool {
+	return h.express
+}
+
+func (h *mockHandler) HandleGeneralMessage(ctx sdk.Context, _, _ string, _ string, _ []byte) error {
+	ctx.GasMeter().ConsumeGas(h.gas, "mock handler")
+	if h.err != nil {
+		return h.err
+	}
+	h.calls = append(h.calls, sdk.Coin{})
+	return nil
+}
+
+func (h *mockHandler) HandleGeneralMessageWithToken(ctx sdk.Context, _, _ string, destAddress string, _ []byte, coin sdk.Coin) error {
+	ctx.GasMeter().ConsumeGas(h.gas, "mock handler")
+	if h.err != nil {
+		return h.err
+	}
+	if h.spend != nil {
+		if err := h.spend(destAddress, coin); err != nil {
+			return err
+		}
+	}
+	h.calls = append(h.calls, coin)
+	return nil
+}
+
+// mockTransferApp credits the receiver of inbound transfers, like the transfer app. The gmp
+// module account is blocked, as in the app.
+type mockTransferApp struct {
+	porttypes.IBCModule
+	bank *mockBankKeeper
+}
+
+func (a mockTransferApp) OnRecvPacket(_ sdk.Context, packet channeltypes.Packet, _ sdk.AccAddress) ibcexported.Acknowledgement {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	if receiver.Equals(testModuleAddr) {
+		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("%s is not allowed to receive funds", receiver))
+	}
+	amount, _ := sdkmath.NewIntFromString(data.Amount)
+	a.bank.mint(receiver, sdk.NewCoins(sdk.NewCoin(parseDenom(packet, data.Denom), amount)))
+	return channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+}
+
+func (a mockTransferApp) OnAcknowledgementPacket(sdk.Context, channeltypes.Packet, []byte, sdk.AccAddress) error {
+	return nil
+}
+
+func (a mockTransferApp) OnTimeoutPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) error {
+	return nil
+}
+
+// testFixture wires a keeper and the gmp middleware to in-memory mocks.
+type testFixture struct {
+	ctx        sdk.Context
+	keeper     *Keeper
+	middleware IBCMiddleware
+	bank       *mockBankKeeper
+	transfer   *mockTransferKeeper
+	circuit    mockCircuitKeeper
+	contracts  *mockContractKeeper
+	handler    *mockHandler
+	sequence   uint64
+}
+
+func newTestFixture(t *testing.T) *testFixture {
+	t.Helper()
+
+	key := storetypes.NewKVStoreKey(StoreKey)
+	ctx := testutil.DefaultContextWithDB(t, key, storetypes.NewTransientStoreKey("transient_test")).Ctx.
+		WithBlockHeight(1).
+		WithBlockTime(time.Unix(1_700_000_000, 0))
+
+	registry := codectypes.NewInterfaceRegistry()
+	RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	bank := &mockBankKeeper{balances: map[string]sdk.Coins{}}
+	transfer := &mockTransferKeeper{bank: bank}
+	circuit := mockCircuitKeeper{permissions: map[string]circuittypes.Permissions{}}
+	contracts := &mockContractKeeper{bank: bank}
+
+	k := NewKeeper(cdc, runtime.NewKVStoreService(key), testAuthority.String(),
+		mockChannelKeeper{chainID: testAxelarChainID}, transfer, bank, circuit)
+	k.SetContractKeeper(contracts)
+
+	handler := &mockHandler{}
+	router := NewRouter()
+	router.AddRoute("", testPayloadVersion, handler)
+	router.AddRoute("", payload.VersionCosmWasmABI.Bytes(), NewWasmHandler(contracts, bank))
+	k.SetRouter(router)
+
+	params := DefaultParams()
+	params.AxelarGmpAccounts = []string{testAxelarAccount}
+	params.AxelarChainId = testAxelarChainID
+	params.AxelarChannels = []AxelarChannel{{PortId: transfertypes.PortID, ChannelId: testChannel}}
+	params.OutboundChannelId = testChannel
+	require.NoError(t, k.SetParams(ctx, params))
+
+	return &testFixture{
+		ctx:        ctx,
+		keeper:     k,
+		middleware: NewIBCMiddleware(mockTransferApp{bank: bank}, k),
+		bank:       bank,
+		transfer:   transfer,
+		circuit:    circuit,
+		contracts:  contracts,
+		handler:    handler,
+	}
+}
+
+// setParams updates the keeper params with update.
+func (f *testFixture) setParams(t *testing.T, update func(*Params)) {
+	t.Helper()
+
+	params := f.keeper.GetParams(f.ctx)
+	update(&params)
+	require.NoError(t, params.Validate())
+	require.NoError(t, f.keeper.SetParams(f.ctx, params))
+}
+
+// testMessage returns a valid general message with token from the test source.
+func testMessage() Message {
+	return Message{
+		SourceChain:   testSourceChain,
+		SourceAddress: testSourceAddress,
+		Payload:       append(append([]byte{}, testPayloadVersion...), "payload"...),
+		Type:          TypeGeneralMessageWithToken,
+		MessageID:     "0xabc-1",
+	}
+}
+
+// testMessageWithID returns testMessage with the given message ID, for tests delivering it in
+// more than one packet.
+func testMessageWithID(id string) Message {
+	msg := testMessage()
+	msg.MessageID = id
+	return msg
+}
+
+// recvPacket delivers amount of the counterparty denom "uaxl" to receiver with msg as memo.
+func (f *testFixture) recvPacket(t *testing.T, receiver string, amount int64, msg Message) ibcexported.Acknowledgement {
+	t.Helper()
+
+	memo, err := json.Marshal(msg)
+	require.NoError(t, err)
+	return f.recvMemo(t, testAxelarAccount, receiver, amount, string(memo))
+}
+
+// recvMemo delivers amount of the counterparty denom "uaxl" from sender to receiver with memo.
+func (f *testFixture) recvMemo(t *testing.T, sender, receiver string, amount int64, memo string) ibcexported.Acknowledgement {
+	t.Helper()
+
+	data := transfertypes.NewFungibleTokenPacketData("uaxl", sdkmath.NewInt(amount).String(), sender, receiver, memo)
+	f.sequence++
+	packet := channeltypes.NewPacket(data.GetBytes(), f.sequence, transfertypes.PortID, "channel-7",
+		transfertypes.PortID, testChannel, clienttypes.NewHeight(1, 1000), 0)
+	return f.middleware.OnRecvPacket(f.ctx, packet, testAddr("relayer"))
+}
+
+// testDenom is the local denom of the "uaxl" tokens delivered by recvPacket.
+func testDenom() string {
+	return transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(transfertypes.PortID, testChannel, "uaxl")).IBCDenom()
+}
+
+// hasEvent reports whether an event of the type of event was emitted on ctx.
+func hasEvent(ctx sdk.Context, eventType string) bool {
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParams(t *testing.T) {
+	f := newTestFixture(t)
+
+	params := f.keeper.GetParams(f.ctx)
+	require.NoError(t, params.Validate())
+	require.True(t, f.keeper.IsAxelarGMPAccount(f.ctx, testAxelarAccount))
+	require.False(t, f.keeper.IsAxelarGMPAccount(f.ctx, testAddr("someone").String()))
+
+	require.NoError(t, f.keeper.ValidateAxelarChannel(f.ctx, transfertypes.PortID, testChannel))
+	require.ErrorIs(t, f.keeper.ValidateAxelarChannel(f.ctx, transfertypes.PortID, "channel-9"), ErrUnauthorizedChannel)
+
+	f.setParams(t, func(p *Params) { p.AxelarChainId = "other-1" })
+	require.ErrorIs(t, f.keeper.ValidateAxelarChannel(f.ctx, transfertypes.PortID, testChannel), ErrUnauthorizedChannel)
+}
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, DefaultParams().Validate())
+
+	for name, update := range map[string]func(*Params){
+		"invalid gmp account":      func(p *Params) { p.AxelarGmpAccounts = []string{"axelar"} },
+		"duplicate gmp account":    func(p *Params) { p.AxelarGmpAccounts = []string{testAxelarAccount, testAxelarAccount} },
+		"channel without chain":    func(p *Params) { p.AxelarChannels = []AxelarChannel{{PortId: "transfer", ChannelId: testChannel}} },
+		"unconfigured outbound":    func(p *Params) { p.OutboundChannelId = testChannel },
+		"zero outbound timeout":    func(p *Params) { p.OutboundTimeout = 0 },
+		"zero replay retention":    func(p *Params) { p.ReplayRetentionBlocks = 0 },
+		"zero replay prune limit":  func(p *Params) { p.ReplayPruneLimit = 0 },
+		"zero express retention":   func(p *Params) { p.ExpressClaimRetentionBlocks = 0 },
+		"zero failed refund limit": func(p *Params) { p.FailedRefundLimit = 0 },
+		"zero max handler gas":     func(p *Params) { p.MaxHandlerGas = 0 },
+		"payload above memo size":  func(p *Params) { p.MaxPayloadSize = p.MaxMemoSize + 1 },
+		"invalid express executor": func(p *Params) {
+			p.ExpressExecutors = []string{"cosmos1invalid"}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := DefaultParams()
+			update(&params)
+			require.Error(t, params.Validate())
+		})
+	}
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	f := newTestFixture(t)
+
+	id := messageKey(testMessage(), "1")
+	gs := DefaultGenesisState()
+	gs.Params = f.keeper.GetParams(f.ctx)
+	gs.ProcessedMessages = []ProcessedMessage{{Id: id, ExpiryHeight: 10}}
+	gs.Pauses = []Pause{{Scope: PauseScopeSourceChain, SourceChain: "ethereum"}}
+	require.NoError(t, gs.Validate())
+
+	f.keeper.InitGenesis(f.ctx, *gs)
+	exported := f.keeper.ExportGenesis(f.ctx)
+	require.Equal(t, gs.Params, exported.Params)
+	require.Equal(t, gs.ProcessedMessages, exported.ProcessedMessages)
+	require.Equal(t, gs.Pauses, exported.Pauses)
+	require.True(t, f.keeper.HasProcessedMessage(f.ctx, id))
+
+	gs.ProcessedMessages = append(gs.ProcessedMessages, ProcessedMessage{Id: id})
+	require.Error(t, gs.Validate())
+}
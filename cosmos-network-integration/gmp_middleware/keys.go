@@ -0,0 +1,71 @@
+package gmp_middleware
+
+const (
+	// ModuleName defines the name of the gmp module.
+	ModuleName = "gmp"
+
+	// StoreKey is the store key string for the gmp module.
+	StoreKey = ModuleName
+)
+
+var (
+	// ParamsKey is the store key for the gmp module parameters.
+	ParamsKey = []byte{0x01}
+
+	// PacketCallbackKeyPrefix is the prefix of outbound packets awaiting a callback.
+	PacketCallbackKeyPrefix = []byte{0x02}
+
+	// ProcessedMessageKeyPrefix is the prefix of inbound messages that were already executed.
+	ProcessedMessageKeyPrefix = []byte{0x03}
+
+	// ProcessedExpiryKeyPrefix indexes processed messages by the height at which they expire.
+	ProcessedExpiryKeyPrefix = []byte{0x04}
+
+	// RecoveryKeyPrefix is the prefix of the recovery escrow of failed messages.
+	RecoveryKeyPrefix = []byte{0x05}
+
+	// ExpressClaimKeyPrefix is the prefix of express executions waiting for their packet.
+	ExpressClaimKeyPrefix = []byte{0x06}
+
+	// PauseKeyPrefix is the prefix of the active pause switches.
+	PauseKeyPrefix = []byte{0x07}
+
+	// RateLimitKeyPrefix is the prefix of the GMP rate limits.
+	RateLimitKeyPrefix = []byte{0x08}
+
+	// RateLimitUsageKeyPrefix is the prefix of the usage of the GMP rate limits.
+	RateLimitUsageKeyPrefix = []byte{0x09}
+
+	// MessageRecordKeyPrefix is the prefix of the inbound message history.
+	MessageRecordKeyPrefix = []byte{0x0A}
+
+	// MessagePacketIndexKeyPrefix indexes the message history by delivering packet.
+	MessagePacketIndexKeyPrefix = []byte{0x0B}
+
+	// MessagePayloadIndexKeyPrefix indexes the message history by payload hash.
+	MessagePayloadIndexKeyPrefix = []byte{0x0C}
+
+	// FailedMessageKeyPrefix is the prefix of the failed messages stored for retry.
+	FailedMessageKeyPrefix = []byte{0x0D}
+
+	// FailedExpiryKeyPrefix indexes failed messages by the height at which they are refunded.
+	FailedExpiryKeyPrefix = []byte{0x0E}
+
+	// RefundKeyPrefix is the prefix of the refunds of failed messages.
+	RefundKeyPrefix = []byte{0x0F}
+
+	// RefundPacketIndexKeyPrefix indexes pending refunds by their outbound packet.
+	RefundPacketIndexKeyPrefix = []byte{0x10}
+
+	// AssetKeyPrefix is the prefix of the registered Axelar assets, by symbol.
+	AssetKeyPrefix = []byte{0x11}
+
+	// AssetDenomIndexKeyPrefix indexes the registered Axelar assets by local denom.
+	AssetDenomIndexKeyPrefix = []byte{0x12}
+
+	// OutboundPacketKeyPrefix is the prefix of outbound GMP packets awaiting their ack or timeout.
+	OutboundPacketKeyPrefix = []byte{0x13}
+
+	// ExpressExpiryKeyPrefix indexes express claims by the height at which they expire.
+	ExpressExpiryKeyPrefix = []byte{0x14}
+)
@@ -0,0 +1,22 @@
+package gmp_middleware
+
+import (
+	"bytes"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnRecvPacketLogsWithModuleContext(t *testing.T) {
+	f := newTestFixture(t)
+	var buf bytes.Buffer
+	f.ctx = f.ctx.WithLogger(log.NewLogger(&buf, log.ColorOption(false)))
+
+	ack := f.recvMemo(t, testAxelarAccount, testAddr("receiver").String(), 100, `{"source_chain":"Ethereum"}`)
+	require.False(t, ack.Success())
+
+	require.Contains(t, buf.String(), "cannot decode gmp memo")
+	require.Contains(t, buf.String(), "module=x/gmp")
+	require.Contains(t, buf.String(), "channel="+testChannel)
+}
@@ -0,0 +1,127 @@
+// Memo decoding: the memo of an inbound GMP packet is a JSON envelope whose optional version
+// field selects its schema. Memos without a version use the schema Axelar sends today and are
+// treated as MemoVersion1. Each version is decoded strictly: unknown fields, trailing data,
+// nesting beyond MaxMemoDepth and memos or payloads above the governance-set size limits are
+// rejected, so a schema change on the Axelar side surfaces as an error acknowledgement instead
+// of being silently misread. Payloads are base64 decoded and checked before any handler runs.
+
+package gmp_middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// MemoVersion1 is the memo schema of Message.
+	MemoVersion1 uint32 = 1
+
+	// MaxMemoDepth is the deepest JSON nesting accepted in a memo. Version 1 memos nest through
+	// the fee and next objects, and the next hop carries opaque contract msgs and forward memos.
+	MaxMemoDepth = 16
+)
+
+// memoVersion reads the version of a memo envelope.
+type memoVersion struct {
+	Version *uint32 `json:"version"`
+}
+
+// memoV1 is the envelope of a version 1 memo.
+type memoV1 struct {
+	Version uint32 `json:"version,omitempty"`
+	Message
+}
+
+// DecodeMemo decodes and validates the GMP message in an inbound memo under the size limits
+// of the module params.
+func (k Keeper) DecodeMemo(ctx sdk.Context, memo string) (Message, error) {
+	params := k.GetParams(ctx)
+	return decodeMemo([]byte(memo), params.MaxMemoSize, params.MaxPayloadSize)
+}
+
+func decodeMemo(memo []byte, maxMemoSize, maxPayloadSize uint64) (Message, error) {
+	if uint64(len(memo)) > maxMemoSize {
+		return Message{}, errorsmod.Wrapf(ErrInvalidMessage, "memo of %d bytes exceeds the limit of %d", len(memo), maxMemoSize)
+	}
+	if err := checkMemoDepth(memo); err != nil {
+		return Message{}, errorsmod.Wrap(ErrInvalidMessage, err.Error())
+	}
+
+	var envelope memoVersion
+	if err := json.Unmarshal(memo, &envelope); err != nil {
+		return Message{}, errorsmod.Wrapf(ErrInvalidMessage, "cannot decode memo: %s", err)
+	}
+
+	version := MemoVersion1
+	if envelope.Version != nil {
+		version = *envelope.Version
+	}
+
+	var msg Message
+	switch version {
+	case MemoVersion1:
+		var v1 memoV1
+		if err := decodeStrict(memo, &v1); err != nil {
+			return Message{}, errorsmod.Wrapf(ErrInvalidMessage, "cannot decode version %d memo: %s", version, err)
+		}
+		msg = v1.Message
+	default:
+		return Message{}, errorsmod.Wrapf(ErrInvalidMessage, "unsupported memo version %d", version)
+	}
+
+	if uint64(len(msg.Payload)) > maxPayloadSize {
+		return Message{}, errorsmod.Wrapf(ErrInvalidMessage, "payload of %d bytes exceeds the limit of %d", len(msg.Payload), maxPayloadSize)
+	}
+	if err := validateMessage(&msg); err != nil {
+		return Message{}, errorsmod.Wrap(ErrInvalidMessage, err.Error())
+	}
+	return msg, nil
+}
+
+// decodeStrict decodes the single JSON value in bz into v, rejecting unknown fields and
+// trailing data.
+func decodeStrict(bz []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after memo")
+	}
+	return nil
+}
+
+// checkMemoDepth returns an error if the JSON objects and arrays in memo nest deeper than
+// MaxMemoDepth. It only counts brackets outside of strings and leaves syntax checks to the
+// decoder.
+func checkMemoDepth(memo []byte) error {
+	depth := 0
+	inString, escaped := false, false
+	for _, c := range memo {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			if depth > MaxMemoDepth {
+				return fmt.Errorf("memo nests deeper than %d levels", MaxMemoDepth)
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Memo routing: the transfer stack serves three memo formats side by side. Axelar GMP memos
+// arrive from a configured Axelar GMP account on an Axelar channel, packet-forward memos carry
+// a top-level "forward" object and ibc-hooks memos a top-level "wasm" object. The MemoRouter
+// sits on top of the stack and classifies every inbound memo before any of the three
+// middlewares sees it. A memo that matches more than one kind, e.g. a GMP packet that also asks
+// to be forwarded, is rejected with an error acknowledgement, so the outcome never depends on
+// the order of the middlewares. GMP deliveries that should continue to a forward or a contract
+// say so in their own next field, see NextHop.
+
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// MemoKind is the kind of middleware an inbound memo is meant for.
+type MemoKind string
+
+const (
+	// MemoKindNone is a plain transfer, without memo or with a memo no middleware acts on.
+	MemoKindNone MemoKind = "none"
+	// MemoKindGMP is an Axelar GMP message.
+	MemoKindGMP MemoKind = "gmp"
+	// MemoKindForward is a packet-forward memo.
+	MemoKindForward MemoKind = "forward"
+	// MemoKindWasm is an ibc-hooks memo.
+	MemoKindWasm MemoKind = "wasm"
+)
+
+// String returns the name of the memo kind.
+func (k MemoKind) String() string {
+	return string(k)
+}
+
+// ClassifyMemo returns the kind of memo. gmp reports whether the packet was sent by an Axelar
+// GMP account over an Axelar channel. It returns ErrAmbiguousMemo if memo matches more than
+// one kind.
+func ClassifyMemo(memo string, gmp bool) (MemoKind, error) {
+	var kinds []MemoKind
+	if gmp {
+		kinds = append(kinds, MemoKindGMP)
+	}
+
+	var fields map[string]json.RawMessage
+	if strings.TrimSpace(memo) != "" && json.Unmarshal([]byte(memo), &fields) == nil {
+		if _, ok := fields[string(MemoKindForward)]; ok {
+			kinds = append(kinds, MemoKindForward)
+		}
+		if _, ok := fields[string(MemoKindWasm)]; ok {
+			kinds = append(kinds, MemoKindWasm)
+		}
+	}
+
+	switch len(kinds) {
+	case 0:
+		return MemoKindNone, nil
+	case 1:
+		return kinds[0], nil
+	default:
+		names := make([]string, len(kinds))
+		for i, kind := range kinds {
+			names[i] = string(kind)
+		}
+		return "", errorsmod.Wrapf(ErrAmbiguousMemo, "memo matches %s", strings.Join(names, ", "))
+	}
+}
+
+var _ porttypes.IBCModule = MemoRouter{}
+
+// MemoRouter rejects inbound transfers whose memo is meant for more than one middleware.
+type MemoRouter struct {
+	app    porttypes.IBCModule
+	keeper *Keeper
+}
+
+// NewMemoRouter creates a new MemoRouter on top of app.
+func NewMemoRouter(app porttypes.IBCModule, k *Keeper) MemoRouter {
+	return MemoRouter{
+		app:    app,
+		keeper: k,
+	}
+}
+
+// OnChanOpenInit handles the initialization of a new IBC channel.
+func (mr MemoRouter) OnChanOpenInit(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID string,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) (string, error) {
+	return mr.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
+}
+
+// OnChanOpenTry handles the handshake process of opening an IBC channel.
+func (mr MemoRouter) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID,
+	channelID string,
+	channelCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	counterpartyVersion string,
+) (string, error) {
+	return mr.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, channelCap, counterparty, counterpartyVersion)
+}
+
+// OnChanOpenAck acknowledges the opening of a channel.
+func (mr MemoRouter) OnChanOpenAck(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	counterpartyChannelID string,
+	counterpartyVersion string,
+) error {
+	return mr.app.OnChanOpenAck(ctx, portID, channelID, counterpartyChannelID, counterpartyVersion)
+}
+
+// OnChanOpenConfirm confirms the opening of a channel.
+func (mr MemoRouter) OnChanOpenConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return mr.app.OnChanOpenConfirm(ctx, portID, channelID)
+}
+
+// OnChanCloseInit handles the initiation of a channel closure.
+func (mr MemoRouter) OnChanCloseInit(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return mr.app.OnChanCloseInit(ctx, portID, channelID)
+}
+
+// OnChanCloseConfirm handles the confirmation of a channel closure.
+func (mr MemoRouter) OnChanCloseConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return mr.app.OnChanCloseConfirm(ctx, portID, channelID)
+}
+
+// OnRecvPacket classifies the memo of an incoming transfer and rejects it if it is ambiguous.
+func (mr MemoRouter) OnRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) ibcexported.Acknowledgement {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		// Not a transfer packet, leave the error to the transfer app.
+		return mr.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	params := mr.keeper.GetParams(ctx)
+	gmp := params.IsAxelarGMPAccount(data.Sender) && params.IsAxelarChannel(packet.GetDestPort(), packet.GetDestChannel())
+
+	kind, err := ClassifyMemo(data.GetMemo(), gmp)
+	if err != nil {
+		mr.keeper.Logger(ctx).Info("rejected ambiguous memo",
+			"channel", packet.GetDestChannel(),
+			"sequence", packet.GetSequence(),
+			"sender", data.Sender,
+			"error", err,
+		)
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+
+	mr.keeper.Logger(ctx).Debug("routing memo", "kind", kind, "channel", packet.GetDestChannel(), "sequence", packet.GetSequence())
+	return mr.app.OnRecvPacket(ctx, packet, relayer)
+}
+
+// OnAcknowledgementPacket handles packet acknowledgments.
+func (mr MemoRouter) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	return mr.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer)
+}
+
+// OnTimeoutPacket handles packet timeouts.
+func (mr MemoRouter) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	return mr.app.OnTimeoutPacket(ctx, packet, relayer)
+}
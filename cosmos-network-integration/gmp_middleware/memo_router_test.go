@@ -0,0 +1,83 @@
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+func TestClassifyMemo(t *testing.T) {
+	for name, tc := range map[string]struct {
+		memo string
+		gmp  bool
+		kind MemoKind
+	}{
+		"empty":         {memo: "", kind: MemoKindNone},
+		"plain text":    {memo: "thanks", kind: MemoKindNone},
+		"other json":    {memo: `{"note":"hi"}`, kind: MemoKindNone},
+		"gmp":           {memo: `{"source_chain":"Ethereum"}`, gmp: true, kind: MemoKindGMP},
+		"forward":       {memo: `{"forward":{"receiver":"osmo1receiver"}}`, kind: MemoKindForward},
+		"wasm":          {memo: `{"wasm":{"contract":"wasm1contract"}}`, kind: MemoKindWasm},
+		"gmp with next": {memo: `{"source_chain":"Ethereum","next":{"forward":{}}}`, gmp: true, kind: MemoKindGMP},
+	} {
+		t.Run(name, func(t *testing.T) {
+			kind, err := ClassifyMemo(tc.memo, tc.gmp)
+			require.NoError(t, err)
+			require.Equal(t, tc.kind, kind)
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		memo string
+		gmp  bool
+	}{
+		"gmp and forward":  {memo: `{"forward":{}}`, gmp: true},
+		"gmp and wasm":     {memo: `{"wasm":{}}`, gmp: true},
+		"forward and wasm": {memo: `{"forward":{},"wasm":{}}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ClassifyMemo(tc.memo, tc.gmp)
+			require.ErrorIs(t, err, ErrAmbiguousMemo)
+		})
+	}
+}
+
+func TestMemoRouterOnRecvPacket(t *testing.T) {
+	f := newTestFixture(t)
+	router := NewMemoRouter(f.middleware, f.keeper)
+	receiver := testAddr("receiver")
+
+	recv := func(sender, memo string) bool {
+		data := transfertypes.NewFungibleTokenPacketData("uaxl", sdkmath.NewInt(100).String(), sender, receiver.String(), memo)
+		f.sequence++
+		packet := channeltypes.NewPacket(data.GetBytes(), f.sequence, transfertypes.PortID, "channel-7",
+			transfertypes.PortID, testChannel, clienttypes.NewHeight(1, 1000), 0)
+		return router.OnRecvPacket(f.ctx, packet, testAddr("relayer")).Success()
+	}
+
+	gmp, err := json.Marshal(testMessage())
+	require.NoError(t, err)
+	require.True(t, recv(testAxelarAccount, string(gmp)))
+	require.Len(t, f.handler.calls, 1)
+
+	// a gmp packet that also asks to be forwarded is rejected before any middleware runs
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(gmp, &fields))
+	fields["forward"] = json.RawMessage(`{"receiver":"osmo1receiver","channel":"channel-1"}`)
+	ambiguous, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.False(t, recv(testAxelarAccount, string(ambiguous)))
+	require.Len(t, f.handler.calls, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(receiver))
+
+	// the same memo from another sender is only a forward memo
+	require.True(t, recv(testAddr("sender").String(), `{"forward":{"receiver":"osmo1receiver","channel":"channel-1"}}`))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 200)), f.bank.balance(receiver))
+}
@@ -0,0 +1,88 @@
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testMemo returns the JSON memo of testMessage with extra fields merged in.
+func testMemo(t *testing.T, extra map[string]interface{}) string {
+	t.Helper()
+
+	bz, err := json.Marshal(testMessage())
+	require.NoError(t, err)
+	fields := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(bz, &fields))
+	for key, value := range extra {
+		fields[key] = value
+	}
+	bz, err = json.Marshal(fields)
+	require.NoError(t, err)
+	return string(bz)
+}
+
+func TestDecodeMemo(t *testing.T) {
+	for name, memo := range map[string]string{
+		"without version": testMemo(t, nil),
+		"version 1":       testMemo(t, map[string]interface{}{"version": 1}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			msg, err := decodeMemo([]byte(memo), DefaultMaxMemoSize, DefaultMaxPayloadSize)
+			require.NoError(t, err)
+			require.Equal(t, testMessage(), msg)
+		})
+	}
+}
+
+func TestDecodeMemoRejectsInvalidMemos(t *testing.T) {
+	deep := strings.Repeat(`{"a":`, MaxMemoDepth) + `{}` + strings.Repeat(`}`, MaxMemoDepth)
+
+	for name, memo := range map[string]string{
+		"unsupported version":  testMemo(t, map[string]interface{}{"version": 2}),
+		"unknown field":        testMemo(t, map[string]interface{}{"gas_limit": 1}),
+		"trailing data":        testMemo(t, nil) + `{}`,
+		"too deep":             deep,
+		"missing source chain": testMemo(t, map[string]interface{}{"source_chain": ""}),
+		"unrecognized type":    testMemo(t, map[string]interface{}{"type": 9}),
+		"not json":             "gmp",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeMemo([]byte(memo), DefaultMaxMemoSize, DefaultMaxPayloadSize)
+			require.ErrorIs(t, err, ErrInvalidMessage)
+		})
+	}
+}
+
+func TestDecodeMemoEnforcesSizeLimits(t *testing.T) {
+	memo := []byte(testMemo(t, nil))
+	payload := testMessage().Payload
+
+	_, err := decodeMemo(memo, uint64(len(memo)), uint64(len(payload)))
+	require.NoError(t, err)
+
+	_, err = decodeMemo(memo, uint64(len(memo))-1, uint64(len(payload)))
+	require.ErrorIs(t, err, ErrInvalidMessage)
+
+	_, err = decodeMemo(memo, uint64(len(memo)), uint64(len(payload))-1)
+	require.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+func TestCheckMemoDepthIgnoresStrings(t *testing.T) {
+	require.NoError(t, checkMemoDepth([]byte(`{"a":"`+strings.Repeat("{[", MaxMemoDepth)+`\"}"}`)))
+	require.Error(t, checkMemoDepth([]byte(strings.Repeat("[", MaxMemoDepth+1))))
+}
+
+func TestOnRecvPacketRejectsOversizedMemo(t *testing.T) {
+	f := newTestFixture(t)
+	memo := testMemo(t, nil)
+	f.setParams(t, func(p *Params) {
+		p.MaxMemoSize = uint64(len(memo)) - 1
+		p.MaxPayloadSize = p.MaxMemoSize
+	})
+
+	require.False(t, f.recvMemo(t, testAxelarAccount, testAddr("receiver").String(), 100, memo).Success())
+	require.Empty(t, f.handler.calls)
+}
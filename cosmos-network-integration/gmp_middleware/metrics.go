@@ -0,0 +1,166 @@
+// Telemetry: GMP traffic is exported through the SDK telemetry package so it shows up next to
+// the other module metrics when the node runs with telemetry enabled. All metrics live under
+// the "gmp" prefix:
+//
+//	gmp_inbound_messages{source_chain,type,outcome}
+//	gmp_outbound_messages{destination_chain,type}
+//	gmp_token_volume{direction,denom}
+//	gmp_handler_latency{source_chain}
+//	gmp_handler_gas{source_chain}
+//
+// Inbound packets are only measured once their acknowledgement is known: a rejected packet
+// only counts as a failed or rejected message, since core IBC reverts everything else it did.
+// Nothing is recorded while checking or simulating transactions, e.g. when the redundant relay
+// ante handler runs a packet during CheckTx.
+
+package gmp_middleware
+
+import (
+	"strconv"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/hashicorp/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// Outcomes of an inbound GMP message, as reported in the outcome label.
+const (
+	outcomeExecuted   = "executed"
+	outcomeFailed     = "failed"
+	outcomeRejected   = "rejected"
+	outcomeRecovered  = "recovered"
+	outcomeReimbursed = "reimbursed"
+	outcomeStored     = "stored"
+	outcomeRefunded   = "refunded"
+)
+
+// Directions of GMP token volume, as reported in the direction label.
+const (
+	directionInbound  = "inbound"
+	directionOutbound = "outbound"
+)
+
+// metricsEnabled reports whether metrics are recorded in ctx, which is only the case when
+// transactions or blocks are executed for real.
+func metricsEnabled(ctx sdk.Context) bool {
+	return !ctx.IsCheckTx() && !ctx.IsReCheckTx() && ctx.ExecMode() != sdk.ExecModeSimulate
+}
+
+// inboundMetrics collects the metrics of an inbound packet until its acknowledgement is known.
+type inboundMetrics struct {
+	sourceChain string
+	msgType     int64
+	outcome     string
+
+	handled      bool
+	handlerStart time.Time
+	handlerGas   uint64
+
+	volume *sdk.Coin
+}
+
+// message sets the outcome of the inbound message.
+func (m *inboundMetrics) message(sourceChain string, msgType int64, outcome string) {
+	m.sourceChain, m.msgType, m.outcome = sourceChain, msgType, outcome
+}
+
+// handler sets the measurements of the handler execution.
+func (m *inboundMetrics) handler(start time.Time, gasUsed uint64) {
+	m.handled, m.handlerStart, m.handlerGas = true, start, gasUsed
+}
+
+// record records the collected metrics of a packet acknowledged with ack. Only the outcome of
+// a rejected packet is recorded.
+func (m inboundMetrics) record(ctx sdk.Context, ack ibcexported.Acknowledgement) {
+	if m.outcome == "" {
+		return
+	}
+
+	recordInboundMessage(ctx, m.sourceChain, m.msgType, m.outcome)
+	if ack != nil && !ack.Success() {
+		return
+	}
+	if m.handled {
+		recordHandlerExecution(ctx, m.sourceChain, m.handlerStart, m.handlerGas)
+	}
+	if m.volume != nil {
+		recordTokenVolume(ctx, directionInbound, m.volume.Denom, m.volume.Amount)
+	}
+}
+
+// recordInboundMessage counts an inbound GMP message by source chain, type and outcome.
+func recordInboundMessage(ctx sdk.Context, sourceChain string, msgType int64, outcome string) {
+	if !metricsEnabled(ctx) {
+		return
+	}
+
+	telemetry.IncrCounterWithLabels(
+		[]string{ModuleName, "inbound", "messages"},
+		1,
+		[]metrics.Label{
+			telemetry.NewLabel("source_chain", sourceChain),
+			telemetry.NewLabel("type", strconv.FormatInt(msgType, 10)),
+			telemetry.NewLabel("outcome", outcome),
+		},
+	)
+}
+
+// failureOutcome returns the outcome of a failed message settled by the failure policy with the given status.
+func failureOutcome(status MessageStatus) string {
+	switch status {
+	case MessageStatusFailedStored:
+		return outcomeStored
+	case MessageStatusRefunding:
+		return outcomeRefunded
+	default:
+		return outcomeRecovered
+	}
+}
+
+// recordOutboundMessage counts an outbound GMP message by destination chain and type.
+func recordOutboundMessage(ctx sdk.Context, destinationChain string, msgType int64) {
+	if !metricsEnabled(ctx) {
+		return
+	}
+
+	telemetry.IncrCounterWithLabels(
+		[]string{ModuleName, "outbound", "messages"},
+		1,
+		[]metrics.Label{
+			telemetry.NewLabel("destination_chain", destinationChain),
+			telemetry.NewLabel("type", strconv.FormatInt(msgType, 10)),
+		},
+	)
+}
+
+// recordTokenVolume adds amount of denom to the GMP token volume in the given direction.
+// Amounts that do not fit in an int64 are skipped, like the transfer module does.
+func recordTokenVolume(ctx sdk.Context, direction, denom string, amount sdkmath.Int) {
+	if !metricsEnabled(ctx) || !amount.IsInt64() {
+		return
+	}
+
+	telemetry.IncrCounterWithLabels(
+		[]string{ModuleName, "token", "volume"},
+		float32(amount.Int64()),
+		[]metrics.Label{
+			telemetry.NewLabel("direction", direction),
+			telemetry.NewLabel("denom", denom),
+		},
+	)
+}
+
+// recordHandlerExecution samples the latency and gas used of a handler execution.
+func recordHandlerExecution(ctx sdk.Context, sourceChain string, start time.Time, gasUsed uint64) {
+	if !telemetry.IsTelemetryEnabled() || !metricsEnabled(ctx) {
+		return
+	}
+
+	labels := []metrics.Label{telemetry.NewLabel("source_chain", sourceChain)}
+	metrics.MeasureSinceWithLabels([]string{ModuleName, "handler", "latency"}, start.UTC(), labels)
+	metrics.AddSampleWithLabels([]string{ModuleName, "handler", "gas"}, float32(gasUsed), labels)
+}
@@ -0,0 +1,74 @@
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// gatherCounters enables in-memory telemetry for the test and returns a function that sums
+// the recorded gmp counters by name and outcome label.
+func gatherCounters(t *testing.T) func() map[string]float64 {
+	t.Helper()
+
+	m, err := telemetry.New(telemetry.Config{Enabled: true, ServiceName: "test"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := telemetry.New(telemetry.Config{})
+		require.NoError(t, err)
+	})
+
+	return func() map[string]float64 {
+		res, err := m.Gather(telemetry.FormatDefault)
+		require.NoError(t, err)
+
+		var summary struct {
+			Counters []struct {
+				Name   string
+				Sum    float64
+				Labels map[string]string
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Metrics, &summary))
+
+		counters := make(map[string]float64)
+		for _, counter := range summary.Counters {
+			name := strings.SplitN(counter.Name, ";", 2)[0]
+			if outcome, ok := counter.Labels["outcome"]; ok {
+				name += "/" + outcome
+			}
+			counters[name] += counter.Sum
+		}
+		return counters
+	}
+}
+
+func TestInboundMetrics(t *testing.T) {
+	counters := gatherCounters(t)
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+
+	// the redundant relay ante handler runs packets during CheckTx
+	checkCtx := f.ctx
+	f.ctx = f.ctx.WithIsCheckTx(true)
+	require.True(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	f.ctx = checkCtx
+	require.Empty(t, counters())
+
+	// a rejected packet only counts as failed, its token volume is reverted
+	msg := testMessage()
+	msg.Payload = []byte{0xff, 0xff, 0xff, 0xff}
+	require.False(t, f.recvPacket(t, receiver, 100, msg).Success())
+	require.Equal(t, map[string]float64{"test.gmp.inbound.messages/failed": 1}, counters())
+
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-2")).Success())
+	require.Equal(t, map[string]float64{
+		"test.gmp.inbound.messages/failed":   1,
+		"test.gmp.inbound.messages/executed": 1,
+		"test.gmp.token.volume":              100,
+	}, counters())
+}
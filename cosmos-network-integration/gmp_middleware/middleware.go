@@ -10,7 +10,11 @@
 // 2. Packet Reception:
 // Middleware processes incoming packets.
 // Extracts relevant data (Sender, Amount, Memo).
+// The memo is decoded strictly against its versioned schema and the size limits in the params.
 // If the Type in the memo is recognized (e.g., GeneralMessage), invokes custom logic in handler.
+// SendToken memos carry no payload and only deliver the tokens to the recipient in the memo.
+// Messages with tokens may name a next hop, a forward or a contract call that the delivered
+// tokens are passed on to once the handler succeeded.
 // 3. Custom Message Processing:
 // Example: If the memo contains a GeneralMessageWithToken, the middleware:
 // Parses the amount and token denomination.
@@ -19,31 +23,35 @@
 package gmp_middleware
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
-	"github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
-	transfertypes "github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
-	channeltypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
-	porttypes "github.com/cosmos/ibc-go/v4/modules/core/05-port/types"
-	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	"github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 )
 
+var _ porttypes.IBCModule = IBCMiddleware{}
+
 type IBCMiddleware struct {
-	app     porttypes.IBCModule
-	handler GeneralMessageHandler
+	app    porttypes.IBCModule
+	keeper *Keeper
 }
 
 // NewIBCMiddleware creates a new instance of IBCMiddleware.
-func NewIBCMiddleware(app porttypes.IBCModule, handler GeneralMessageHandler) IBCMiddleware {
-	log.Println("Initializing IBC Middleware")
+// The GeneralMessageHandler is looked up on the keeper, so it can be registered after the
+// transfer stack is built.
+func NewIBCMiddleware(app porttypes.IBCModule, k *Keeper) IBCMiddleware {
 	return IBCMiddleware{
-		app:     app,
-		handler: handler,
+		app:    app,
+		keeper: k,
 	}
 }
 
@@ -58,7 +66,7 @@ func (im IBCMiddleware) OnChanOpenInit(
 	counterparty channeltypes.Counterparty,
 	version string,
 ) (string, error) {
-	log.Printf("OnChanOpenInit called with portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanOpenInit", "port", portID, "channel", channelID)
 	return im.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
 }
 
@@ -73,7 +81,7 @@ func (im IBCMiddleware) OnChanOpenTry(
 	counterparty channeltypes.Counterparty,
 	counterpartyVersion string,
 ) (string, error) {
-	log.Printf("OnChanOpenTry called with portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanOpenTry", "port", portID, "channel", channelID)
 	return im.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, channelCap, counterparty, counterpartyVersion)
 }
 
@@ -85,7 +93,7 @@ func (im IBCMiddleware) OnChanOpenAck(
 	counterpartyChannelID string,
 	counterpartyVersion string,
 ) error {
-	log.Printf("OnChanOpenAck called with portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanOpenAck", "port", portID, "channel", channelID)
 	return im.app.OnChanOpenAck(ctx, portID, channelID, counterpartyChannelID, counterpartyVersion)
 }
 
@@ -95,7 +103,7 @@ func (im IBCMiddleware) OnChanOpenConfirm(
 	portID,
 	channelID string,
 ) error {
-	log.Printf("OnChanOpenConfirm called with portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanOpenConfirm", "port", portID, "channel", channelID)
 	return im.app.OnChanOpenConfirm(ctx, portID, channelID)
 }
 
@@ -105,7 +113,7 @@ func (im IBCMiddleware) OnChanCloseInit(
 	portID,
 	channelID string,
 ) error {
-	log.Printf("OnChanCloseInit called for portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanCloseInit", "port", portID, "channel", channelID)
 	return im.app.OnChanCloseInit(ctx, portID, channelID)
 }
 
@@ -115,7 +123,7 @@ func (im IBCMiddleware) OnChanCloseConfirm(
 	portID,
 	channelID string,
 ) error {
-	log.Printf("OnChanCloseConfirm called for portID: %s, channelID: %s", portID, channelID)
+	im.keeper.Logger(ctx).Debug("OnChanCloseConfirm", "port", portID, "channel", channelID)
 	return im.app.OnChanCloseConfirm(ctx, portID, channelID)
 }
 
@@ -125,55 +133,200 @@ func (im IBCMiddleware) OnRecvPacket(
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
 ) ibcexported.Acknowledgement {
-	log.Printf("OnRecvPacket called with packet sequence: %d", packet.Sequence)
+	var metrics inboundMetrics
+	ack := im.onRecvPacket(ctx, packet, relayer, &metrics)
+	metrics.record(ctx, ack)
+	return ack
+}
+
+// onRecvPacket processes an incoming packet and collects its metrics, which are only recorded
+// once the acknowledgement is known.
+func (im IBCMiddleware) onRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+	metrics *inboundMetrics,
+) ibcexported.Acknowledgement {
+	logger := im.keeper.Logger(ctx).With("channel", packet.GetDestChannel(), "sequence", packet.GetSequence())
+	logger.Debug("OnRecvPacket")
 
 	ack := im.app.OnRecvPacket(ctx, packet, relayer)
 	if !ack.Success() {
-		log.Printf("Failed to process packet: %v", ack)
+		logger.Debug("transfer app rejected packet")
 		return ack
 	}
 
 	var data transfertypes.FungibleTokenPacketData
 	if err := types.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
-		log.Printf("Error unmarshalling packet data: %v", err)
+		logger.Debug("cannot unmarshal packet data", "error", err)
 		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("failed to unmarshal ICS-20 transfer packet data: %w", err))
 	}
 
-	if data.Sender != AxelarGMPAcc {
-		log.Printf("Unauthorized sender: %s", data.Sender)
+	if !im.keeper.IsAxelarGMPAccount(ctx, data.Sender) {
+		logger.Debug("not a gmp packet", "sender", data.Sender)
 		return ack
 	}
 
-	var msg Message
-	if err := json.Unmarshal([]byte(data.GetMemo()), &msg); err != nil {
-		log.Printf("Error unmarshalling memo: %v", err)
-		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("failed to unmarshal memo: %w", err))
+	// The sender string is chosen by the counterparty, so also require that the packet
+	// arrived over an authenticated Axelar channel.
+	if err := im.keeper.ValidateAxelarChannel(ctx, packet.GetDestPort(), packet.GetDestChannel()); err != nil {
+		logger.Info("rejected gmp packet on unauthorized channel", "sender", data.Sender, "error", err)
+		im.keeper.EmitEvent(ctx, &EventSenderRejected{
+			Sender:    data.Sender,
+			PortId:    packet.GetDestPort(),
+			ChannelId: packet.GetDestChannel(),
+			Sequence:  packet.GetSequence(),
+			Error:     err.Error(),
+		})
+		metrics.message("", TypeUnrecognized, outcomeRejected)
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+
+	msg, err := im.keeper.DecodeMemo(ctx, data.GetMemo())
+	if err != nil {
+		logger.Info("cannot decode gmp memo", "error", err)
+		im.keeper.EmitEvent(ctx, newInboundEvent(packet, data, Message{}).failed(err))
+		metrics.message("", TypeUnrecognized, outcomeFailed)
+		return channeltypes.NewErrorAcknowledgement(err)
 	}
 
-	var err error
+	logger = logger.With("source_chain", msg.SourceChain, "source_address", msg.SourceAddress)
+
+	event := newInboundEvent(packet, data, msg)
+	im.keeper.EmitEvent(ctx, event.received())
+
+	// fail reports a message that could not be executed and rejects the packet. Core IBC
+	// re-emits the events of rejected packets under their "ibccallbackerror-" error types.
+	fail := func(err error) ibcexported.Acknowledgement {
+		logger.Info("gmp message failed", "error", err)
+		im.keeper.EmitEvent(ctx, event.failed(err))
+		metrics.message(msg.SourceChain, msg.Type, outcomeFailed)
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+
+	messageID := MessageID(packet, msg)
+	if im.keeper.HasProcessedMessage(ctx, messageID) || im.keeper.HasFailedMessage(ctx, messageID) {
+		err := errorsmod.Wrapf(ErrDuplicateMessage, "message %X from %s/%s", messageID, msg.SourceChain, msg.SourceAddress)
+		return fail(err)
+	}
+
+	if err := im.keeper.CheckPaused(ctx, msg); err != nil {
+		return fail(err)
+	}
+
+	amt, ok := sdkmath.NewIntFromString(data.Amount)
+	if !ok {
+		return fail(errorsmod.Wrapf(transfertypes.ErrInvalidAmount, "invalid transfer amount: %s", data.Amount))
+	}
+	token := sdk.NewCoin(event.denom, amt)
+	received := token
+
+	if msg.Fee != nil {
+		remaining, err := im.keeper.PayFee(ctx, data.Receiver, token, *msg.Fee)
+		if err != nil {
+			return fail(err)
+		}
+		im.keeper.EmitEvent(ctx, &EventFeePaid{
+			SourceChain:   msg.SourceChain,
+			SourceAddress: msg.SourceAddress,
+			Recipient:     msg.Fee.Recipient,
+			Denom:         token.Denom,
+			Amount:        token.Amount.Sub(remaining.Amount).String(),
+			ChannelId:     packet.GetDestChannel(),
+			Sequence:      packet.GetSequence(),
+		})
+		token = remaining
+	}
+
+	// An express executor already ran the handler, so only pay it back with the delivered tokens.
+	if claim, found := im.keeper.GetExpressClaim(ctx, packetExpressKey(msg, data.Receiver, token)); found {
+		reimbursement, err := im.keeper.SettleExpressClaim(ctx, claim)
+		if err != nil {
+			return fail(err)
+		}
+
+		reimbursed := &EventExpressReimbursed{
+			Executor:      claim.Executor,
+			SourceChain:   msg.SourceChain,
+			SourceAddress: msg.SourceAddress,
+			ChannelId:     packet.GetDestChannel(),
+			Sequence:      packet.GetSequence(),
+		}
+		if !reimbursement.Amount.IsNil() {
+			reimbursed.Denom, reimbursed.Amount = reimbursement.Denom, reimbursement.Amount.String()
+		}
+		im.keeper.EmitEvent(ctx, reimbursed)
+		metrics.message(msg.SourceChain, msg.Type, outcomeReimbursed)
+		im.keeper.SetMessageRecord(ctx, newMessageRecord(ctx, messageID, msg, event, MessageStatusExpressReimbursed, nil))
+
+		im.keeper.SetProcessedMessage(ctx, messageID)
+		return ack
+	}
+
+	// Expressed messages were charged when they were executed, the others are charged here.
+	if err := im.keeper.ConsumeRateLimits(ctx, msg, data.Receiver, received); err != nil {
+		return fail(err)
+	}
+
+	// Token deliveries run no handler.
+	var handler GeneralMessageHandler
+	if msg.Type != TypeSendToken {
+		var err error
+		handler, err = im.keeper.GetGeneralMessageHandler(data.Receiver, msg.Payload)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	// coin is only set for messages that hand tokens to the handler.
+	var coin *sdk.Coin
+	var execute func(sdk.Context) error
 	switch msg.Type {
 	case TypeGeneralMessage:
-		err = im.handler.HandleGeneralMessage(ctx, msg.SourceChain, msg.SourceAddress, data.Receiver, msg.Payload)
+		execute = func(ctx sdk.Context) error {
+			return handler.HandleGeneralMessage(ctx, msg.SourceChain, msg.SourceAddress, data.Receiver, msg.Payload)
+		}
 	case TypeGeneralMessageWithToken:
-		amt, ok := sdk.NewIntFromString(data.Amount)
-		if !ok {
-			log.Printf("Invalid transfer amount: %s", data.Amount)
-			return channeltypes.NewErrorAcknowledgement(sdkerrors.Wrapf(transfertypes.ErrInvalidAmount, "invalid transfer amount: %s", data.Amount))
+		coin = &token
+		execute = func(ctx sdk.Context) error {
+			return handler.HandleGeneralMessageWithToken(ctx, msg.SourceChain, msg.SourceAddress, data.Receiver, msg.Payload, token)
+		}
+	case TypeSendToken:
+		coin = &token
+		execute = func(ctx sdk.Context) error {
+			return im.keeper.DeliverToken(ctx, msg, data.Receiver, token)
 		}
-
-		denom := parseDenom(packet, data.Denom)
-		err = im.handler.HandleGeneralMessageWithToken(ctx, msg.SourceChain, msg.SourceAddress, data.Receiver, msg.Payload, sdk.NewCoin(denom, amt))
 	default:
-		err = fmt.Errorf("unrecognized message type: %d", msg.Type)
-		log.Printf("Error: %v", err)
+		return fail(fmt.Errorf("unrecognized message type: %d", msg.Type))
 	}
+	if coin != nil {
+		execute = im.keeper.chainNextHop(execute, msg, data.Receiver, *coin)
+	}
+
+	// Run the handler on a branch of the packet context so a failing handler leaves no
+	// partial state behind, then let the failure policy settle the tokens.
+	start := time.Now()
+	gasUsed, err := im.keeper.ExecuteHandler(ctx, im.keeper.GetParams(ctx).MaxHandlerGas, execute)
+	metrics.handler(start, gasUsed)
 
 	if err != nil {
-		log.Printf("Error processing message: %v", err)
-		return channeltypes.NewErrorAcknowledgement(err)
+		status, failErr := im.keeper.HandleFailure(ctx, messageID, msg, data.Receiver, coin, err)
+		if failErr != nil {
+			return fail(failErr)
+		}
+		im.keeper.EmitEvent(ctx, event.failed(err))
+		metrics.message(msg.SourceChain, msg.Type, failureOutcome(status))
+		im.keeper.SetMessageRecord(ctx, newMessageRecord(ctx, messageID, msg, event, status, err))
+	} else {
+		logger.Debug("gmp message executed", "destination", data.Receiver)
+		im.keeper.EmitEvent(ctx, event.executed())
+		metrics.message(msg.SourceChain, msg.Type, outcomeExecuted)
+		metrics.volume = coin
+		im.keeper.SetMessageRecord(ctx, newMessageRecord(ctx, messageID, msg, event, MessageStatusExecuted, nil))
 	}
 
-	log.Println("Packet successfully processed")
+	im.keeper.SetProcessedMessage(ctx, messageID)
+
 	return ack
 }
 
@@ -184,8 +337,13 @@ func (im IBCMiddleware) OnAcknowledgementPacket(
 	acknowledgement []byte,
 	relayer sdk.AccAddress,
 ) error {
-	log.Printf("OnAcknowledgementPacket called for packet sequence: %d", packet.Sequence)
-	return im.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer)
+	im.keeper.Logger(ctx).Debug("OnAcknowledgementPacket", "channel", packet.GetSourceChannel(), "sequence", packet.GetSequence())
+	if err := im.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+
+	im.keeper.OnAcknowledgementPacket(ctx, packet, acknowledgement)
+	return nil
 }
 
 // OnTimeoutPacket handles packet timeouts.
@@ -194,6 +352,11 @@ func (im IBCMiddleware) OnTimeoutPacket(
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
 ) error {
-	log.Printf("OnTimeoutPacket called for packet sequence: %d", packet.Sequence)
-	return im.app.OnTimeoutPacket(ctx, packet, relayer)
+	im.keeper.Logger(ctx).Debug("OnTimeoutPacket", "channel", packet.GetSourceChannel(), "sequence", packet.GetSequence())
+	if err := im.app.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+
+	im.keeper.OnTimeoutPacket(ctx, packet)
+	return nil
 }
@@ -0,0 +1,47 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestOnRecvPacketExecutesHandler(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver")
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Equal(t, []sdk.Coin{sdk.NewInt64Coin(testDenom(), 100)}, f.handler.calls)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(receiver))
+}
+
+func TestOnRecvPacketIgnoresOtherSenders(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver")
+
+	ack := f.recvMemo(t, testAddr("sender").String(), receiver.String(), 100, `{"not":"a gmp memo"}`)
+	require.True(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(receiver))
+}
+
+func TestOnRecvPacketRejectsInvalidMemo(t *testing.T) {
+	f := newTestFixture(t)
+
+	ack := f.recvMemo(t, testAxelarAccount, testAddr("receiver").String(), 100, `{"source_chain":"Ethereum"}`)
+	require.False(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+}
+
+func TestOnRecvPacketRejectsUnauthorizedChannel(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.AxelarChainId = "impostor-1" })
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, testMessage())
+	require.False(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventSenderRejected"))
+}
@@ -0,0 +1,236 @@
+package gmp_middleware
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+type msgServer struct {
+	*Keeper
+}
+
+var _ MsgServer = msgServer{}
+
+// NewMsgServerImpl returns an implementation of the gmp MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(k *Keeper) MsgServer {
+	return &msgServer{Keeper: k}
+}
+
+// UpdateParams updates the gmp module parameters. Only the module authority may call it.
+func (k msgServer) UpdateParams(goCtx context.Context, msg *MsgUpdateParams) (*MsgUpdateParamsResponse, error) {
+	if k.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	if err := msg.Params.Validate(); err != nil {
+		return nil, errorsmod.Wrap(ErrInvalidParams, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.SetParams(ctx, msg.Params); err != nil {
+		return nil, err
+	}
+
+	return &MsgUpdateParamsResponse{}, nil
+}
+
+// ClaimRecovery releases the recovery escrow of a source address to the calling recovery address.
+func (k msgServer) ClaimRecovery(goCtx context.Context, msg *MsgClaimRecovery) (*MsgClaimRecoveryResponse, error) {
+	claimer, err := sdk.AccAddressFromBech32(msg.Claimer)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	coins, err := k.Keeper.ClaimRecovery(ctx, claimer, msg.SourceChain, msg.SourceAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MsgClaimRecoveryResponse{Coins: coins}, nil
+}
+
+// ExpressExecute executes an inbound GMP message ahead of its packet on behalf of an express executor.
+func (k msgServer) ExpressExecute(goCtx context.Context, msg *MsgExpressExecute) (*MsgExpressExecuteResponse, error) {
+	executor, err := sdk.AccAddressFromBech32(msg.Executor)
+	if err != nil {
+		return nil, err
+	}
+	if !msg.Coin.Amount.IsNil() && !msg.Coin.IsValid() {
+		return nil, errorsmod.Wrapf(ErrInvalidMessage, "invalid coin %s", msg.Coin)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	gmpMsg := Message{
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Payload:       msg.Payload,
+		MessageID:     msg.MessageId,
+	}
+	if err := k.Keeper.ExpressExecute(ctx, executor, gmpMsg, msg.DestinationAddress, msg.Coin); err != nil {
+		return nil, err
+	}
+
+	return &MsgExpressExecuteResponse{}, nil
+}
+
+// RetryGeneralMessage re-executes a stored failed message. Anyone may call it.
+func (k msgServer) RetryGeneralMessage(goCtx context.Context, msg *MsgRetryGeneralMessage) (*MsgRetryGeneralMessageResponse, error) {
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.RetryFailedMessage(ctx, sender, msg.Id); err != nil {
+		return nil, err
+	}
+
+	return &MsgRetryGeneralMessageResponse{}, nil
+}
+
+// RetryRefund sends a failed refund again. Anyone may call it.
+func (k msgServer) RetryRefund(goCtx context.Context, msg *MsgRetryRefund) (*MsgRetryRefundResponse, error) {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	sequence, err := k.Keeper.RetryRefund(ctx, msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MsgRetryRefundResponse{Sequence: sequence}, nil
+}
+
+// Pause trips a pause switch on behalf of the module authority or a circuit breaker account.
+func (k msgServer) Pause(goCtx context.Context, msg *MsgPause) (*MsgPauseResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.authorizePause(ctx, msg.Authority, sdk.MsgTypeURL(msg)); err != nil {
+		return nil, err
+	}
+
+	pause := Pause{
+		Scope:         msg.Scope,
+		SourceChain:   normalizeChain(msg.SourceChain),
+		SourceAddress: msg.SourceAddress,
+		MessageType:   msg.MessageType,
+		PausedBy:      msg.Authority,
+	}
+	if err := pause.Validate(); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	k.SetPause(ctx, pause)
+	k.EmitEvent(ctx, &EventPaused{
+		Scope:         pause.Scope.String(),
+		SourceChain:   pause.SourceChain,
+		SourceAddress: pause.SourceAddress,
+		MessageType:   pause.MessageType,
+		Authority:     msg.Authority,
+	})
+
+	return &MsgPauseResponse{}, nil
+}
+
+// Unpause resets a pause switch on behalf of the module authority or a circuit breaker account.
+func (k msgServer) Unpause(goCtx context.Context, msg *MsgUnpause) (*MsgUnpauseResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.authorizePause(ctx, msg.Authority, sdk.MsgTypeURL(msg)); err != nil {
+		return nil, err
+	}
+
+	pause := Pause{
+		Scope:         msg.Scope,
+		SourceChain:   normalizeChain(msg.SourceChain),
+		SourceAddress: msg.SourceAddress,
+		MessageType:   msg.MessageType,
+	}
+	if err := pause.Validate(); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	if !k.DeletePause(ctx, pause) {
+		return nil, errorsmod.Wrapf(ErrNotPaused, "%s", pause.describe())
+	}
+	k.EmitEvent(ctx, &EventUnpaused{
+		Scope:         pause.Scope.String(),
+		SourceChain:   pause.SourceChain,
+		SourceAddress: pause.SourceAddress,
+		MessageType:   pause.MessageType,
+		Authority:     msg.Authority,
+	})
+
+	return &MsgUnpauseResponse{}, nil
+}
+
+// SetRateLimit adds or replaces a GMP rate limit. Only the module authority may call it.
+func (k msgServer) SetRateLimit(goCtx context.Context, msg *MsgSetRateLimit) (*MsgSetRateLimitResponse, error) {
+	if k.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	rl := msg.RateLimit
+	rl.SourceChain = normalizeChain(rl.SourceChain)
+	if err := rl.Validate(); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	k.Keeper.SetRateLimit(ctx, rl)
+
+	return &MsgSetRateLimitResponse{}, nil
+}
+
+// RemoveRateLimit removes a GMP rate limit. Only the module authority may call it.
+func (k msgServer) RemoveRateLimit(goCtx context.Context, msg *MsgRemoveRateLimit) (*MsgRemoveRateLimitResponse, error) {
+	if k.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if !k.DeleteRateLimit(ctx, msg.SourceChain, msg.SourceAddress, msg.Destination) {
+		return nil, errorsmod.Wrapf(ErrNoRateLimit, "source %s/%s to %s", msg.SourceChain, msg.SourceAddress, msg.Destination)
+	}
+
+	return &MsgRemoveRateLimitResponse{}, nil
+}
+
+// RegisterAsset adds or replaces an Axelar asset. Only the module authority may call it.
+func (k msgServer) RegisterAsset(goCtx context.Context, msg *MsgRegisterAsset) (*MsgRegisterAssetResponse, error) {
+	if k.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	if err := msg.Asset.Validate(); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.Keeper.RegisterAsset(ctx, msg.Asset); err != nil {
+		return nil, err
+	}
+
+	return &MsgRegisterAssetResponse{}, nil
+}
+
+// RemoveAsset removes an Axelar asset. Only the module authority may call it.
+func (k msgServer) RemoveAsset(goCtx context.Context, msg *MsgRemoveAsset) (*MsgRemoveAssetResponse, error) {
+	if k.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if !k.DeleteAsset(ctx, msg.Symbol) {
+		return nil, errorsmod.Wrapf(ErrNoAsset, "symbol %s", msg.Symbol)
+	}
+
+	return &MsgRemoveAssetResponse{}, nil
+}
@@ -0,0 +1,163 @@
+// Outbound GMP: any module can send a general message to an EVM or Cosmos chain through Axelar.
+// The message travels as the memo of an ICS-20 transfer to the Axelar GMP account, using the
+// destination_chain/destination_address/payload/type/fee schema that Axelar expects.
+
+package gmp_middleware
+
+import (
+	"encoding/json"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// SendGeneralMessage sends a pure GMP message to destinationAddress on destinationChain and
+// returns the sequence of the IBC packet that carries it.
+// Axelar requires a token with every packet, so fee is transferred along with the message.
+// If feeRecipient is set, the whole fee is paid to it on Axelar.
+func (k Keeper) SendGeneralMessage(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	destinationChain, destinationAddress string,
+	payload []byte,
+	fee sdk.Coin,
+	feeRecipient string,
+) (uint64, error) {
+	var memoFee *Fee
+	if feeRecipient != "" {
+		memoFee = &Fee{Amount: fee.Amount.String(), Recipient: feeRecipient}
+	}
+
+	return k.sendMessage(ctx, sender, OutboundMessage{
+		DestinationChain:   destinationChain,
+		DestinationAddress: destinationAddress,
+		Payload:            payload,
+		Type:               TypeGeneralMessage,
+		Fee:                memoFee,
+	}, fee)
+}
+
+// SendGeneralMessageWithToken sends a GMP message together with coin to destinationAddress on
+// destinationChain and returns the sequence of the IBC packet that carries it.
+// If fee is set, fee.Amount of coin is paid to fee.Recipient and the rest is delivered.
+func (k Keeper) SendGeneralMessageWithToken(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	destinationChain, destinationAddress string,
+	payload []byte,
+	coin sdk.Coin,
+	fee *Fee,
+) (uint64, error) {
+	return k.sendMessage(ctx, sender, OutboundMessage{
+		DestinationChain:   destinationChain,
+		DestinationAddress: destinationAddress,
+		Payload:            payload,
+		Type:               TypeGeneralMessageWithToken,
+		Fee:                fee,
+	}, coin)
+}
+
+// SendToken sends coin to destinationAddress on destinationChain through Axelar, without a
+// payload, and returns the sequence of the IBC packet that carries it.
+func (k Keeper) SendToken(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	destinationChain, destinationAddress string,
+	coin sdk.Coin,
+) (uint64, error) {
+	return k.sendMessage(ctx, sender, OutboundMessage{
+		DestinationChain:   destinationChain,
+		DestinationAddress: destinationAddress,
+		Type:               TypeSendToken,
+	}, coin)
+}
+
+// sendMessage validates msg, encodes it as the memo of a transfer of token to the Axelar GMP
+// account over the configured outbound channel, and returns the packet sequence.
+// token may name a registered Axelar asset by its symbol.
+func (k Keeper) sendMessage(ctx sdk.Context, sender sdk.AccAddress, msg OutboundMessage, token sdk.Coin) (uint64, error) {
+	token = k.ResolveCoin(ctx, token)
+	if err := validateOutboundMessage(msg, token); err != nil {
+		return 0, err
+	}
+
+	params := k.GetParams(ctx)
+	if params.OutboundChannelId == "" {
+		return 0, errorsmod.Wrap(ErrOutboundDisabled, "outbound channel not set")
+	}
+	if asset, found := k.GetAssetByDenom(ctx, token.Denom); found && asset.ChannelId != params.OutboundChannelId {
+		return 0, errorsmod.Wrapf(ErrInvalidMessage, "asset %s arrives on channel %s, not on the outbound channel %s", asset.Symbol, asset.ChannelId, params.OutboundChannelId)
+	}
+	if len(params.AxelarGmpAccounts) == 0 {
+		return 0, errorsmod.Wrap(ErrOutboundDisabled, "axelar gmp account not set")
+	}
+
+	memo, err := json.Marshal(msg)
+	if err != nil {
+		return 0, errorsmod.Wrapf(ErrInvalidMessage, "cannot marshal memo: %s", err)
+	}
+
+	transfer := transfertypes.NewMsgTransfer(
+		transfertypes.PortID,
+		params.OutboundChannelId,
+		token,
+		sender.String(),
+		params.AxelarGmpAccounts[0],
+		clienttypes.ZeroHeight(),
+		uint64(ctx.BlockTime().Add(params.OutboundTimeout).UnixNano()),
+		string(memo),
+	)
+
+	res, err := k.transferKeeper.Transfer(ctx, transfer)
+	if err != nil {
+		return 0, err
+	}
+	k.setOutboundPacket(ctx, params.OutboundChannelId, res.Sequence)
+
+	k.Logger(ctx).Info("sent gmp message",
+		"destination_chain", msg.DestinationChain,
+		"destination_address", msg.DestinationAddress,
+		"channel", params.OutboundChannelId,
+		"sequence", res.Sequence,
+	)
+
+	k.EmitEvent(ctx, &EventMessageSent{
+		Sender:             sender.String(),
+		DestinationChain:   msg.DestinationChain,
+		DestinationAddress: msg.DestinationAddress,
+		PayloadHash:        PayloadHash(msg.Payload),
+		Denom:              token.Denom,
+		Amount:             token.Amount.String(),
+		ChannelId:          params.OutboundChannelId,
+		Sequence:           res.Sequence,
+		MessageType:        msg.Type,
+	})
+	recordOutboundMessage(ctx, msg.DestinationChain, msg.Type)
+	recordTokenVolume(ctx, directionOutbound, token.Denom, token.Amount)
+
+	return res.Sequence, nil
+}
+
+// validateOutboundMessage ensures that an outbound message and the token it carries are well formed.
+func validateOutboundMessage(msg OutboundMessage, token sdk.Coin) error {
+	if msg.DestinationChain == "" {
+		return errorsmod.Wrap(ErrInvalidMessage, "destination chain is required")
+	}
+	if msg.DestinationAddress == "" {
+		return errorsmod.Wrap(ErrInvalidMessage, "destination address is required")
+	}
+	if !token.IsValid() || !token.IsPositive() {
+		return errorsmod.Wrapf(ErrInvalidMessage, "invalid token %s", token)
+	}
+
+	if msg.Fee != nil {
+		if _, err := msg.Fee.Validate(token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
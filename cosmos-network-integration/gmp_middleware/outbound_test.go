@@ -0,0 +1,65 @@
+package gmp_middleware
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSendGeneralMessage(t *testing.T) {
+	f := newTestFixture(t)
+	sender := testAddr("sender")
+	fee := sdk.NewInt64Coin("stake", 10)
+	f.bank.mint(sender, sdk.NewCoins(fee))
+
+	sequence, err := f.keeper.SendGeneralMessage(f.ctx, sender, "Ethereum", testSourceAddress, []byte("payload"), fee, "axelar1gasreceiver")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence)
+	require.True(t, f.bank.balance(sender).IsZero())
+
+	require.Len(t, f.transfer.transfers, 1)
+	transfer := f.transfer.transfers[0]
+	require.Equal(t, testChannel, transfer.SourceChannel)
+	require.Equal(t, testAxelarAccount, transfer.Receiver)
+	require.Equal(t, fee, transfer.Token)
+
+	var memo OutboundMessage
+	require.NoError(t, json.Unmarshal([]byte(transfer.Memo), &memo))
+	require.Equal(t, OutboundMessage{
+		DestinationChain:   "Ethereum",
+		DestinationAddress: testSourceAddress,
+		Payload:            []byte("payload"),
+		Type:               TypeGeneralMessage,
+		Fee:                &Fee{Amount: "10", Recipient: "axelar1gasreceiver"},
+	}, memo)
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventMessageSent"))
+}
+
+func TestSendGeneralMessageWithToken(t *testing.T) {
+	f := newTestFixture(t)
+	sender := testAddr("sender")
+	coin := sdk.NewInt64Coin("stake", 100)
+	f.bank.mint(sender, sdk.NewCoins(coin))
+
+	_, err := f.keeper.SendGeneralMessageWithToken(f.ctx, sender, "Ethereum", testSourceAddress, []byte("payload"), coin, &Fee{Amount: "101", Recipient: "axelar1gasreceiver"})
+	require.ErrorIs(t, err, ErrInvalidMessage)
+
+	_, err = f.keeper.SendGeneralMessageWithToken(f.ctx, sender, "", testSourceAddress, []byte("payload"), coin, nil)
+	require.ErrorIs(t, err, ErrInvalidMessage)
+
+	_, err = f.keeper.SendGeneralMessageWithToken(f.ctx, sender, "Ethereum", testSourceAddress, []byte("payload"), coin, nil)
+	require.NoError(t, err)
+	require.Len(t, f.transfer.transfers, 1)
+}
+
+func TestSendGeneralMessageWithoutOutboundChannel(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.OutboundChannelId = "" })
+
+	_, err := f.keeper.SendToken(f.ctx, testAddr("sender"), "Ethereum", testSourceAddress, sdk.NewInt64Coin("stake", 1))
+	require.ErrorIs(t, err, ErrOutboundDisabled)
+	require.Empty(t, f.transfer.transfers)
+}
@@ -0,0 +1,161 @@
+package gmp_middleware
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+const (
+	// DefaultOutboundTimeout is the default timeout of outbound GMP packets.
+	DefaultOutboundTimeout = 6 * time.Hour
+	// DefaultMaxHandlerGas is the default gas limit of a GMP handler execution.
+	DefaultMaxHandlerGas uint64 = 1_000_000
+	// DefaultMaxMemoSize is the default size limit of an inbound GMP memo.
+	DefaultMaxMemoSize uint64 = 64 * 1024
+	// DefaultMaxPayloadSize is the default size limit of a decoded inbound GMP payload.
+	DefaultMaxPayloadSize uint64 = 32 * 1024
+)
+
+// DefaultParams returns the default gmp module parameters.
+// No Axelar account is trusted until one is set through genesis or governance.
+func DefaultParams() Params {
+	return Params{
+		AxelarGmpAccounts: []string{},
+		AxelarChainId:     "",
+		AxelarChannels:    []AxelarChannel{},
+		OutboundChannelId: "",
+		OutboundTimeout:   DefaultOutboundTimeout,
+		// roughly one week at 6s blocks
+		ReplayRetentionBlocks: 100_800,
+		ReplayPruneLimit:      1_000,
+		FailurePolicy:         FailurePolicyErrorAck,
+		MaxHandlerGas:         DefaultMaxHandlerGas,
+		ExpressExecutors:      []string{},
+		// roughly one week at 6s blocks
+		FailedMessageRetentionBlocks: 100_800,
+		MaxMemoSize:                  DefaultMaxMemoSize,
+		MaxPayloadSize:               DefaultMaxPayloadSize,
+		RecoveryAddress:              "",
+		FailedRefundLimit:            100,
+		// roughly one day at 6s blocks
+		ExpressClaimRetentionBlocks: 14_400,
+	}
+}
+
+// Validate performs basic validation of the gmp module parameters.
+func (p Params) Validate() error {
+	seen := make(map[string]bool, len(p.AxelarGmpAccounts))
+	for _, acc := range p.AxelarGmpAccounts {
+		// Axelar accounts carry the axelar bech32 prefix, so only the encoding is checked here.
+		if _, _, err := bech32.DecodeAndConvert(acc); err != nil {
+			return fmt.Errorf("invalid axelar gmp account %q: %w", acc, err)
+		}
+		if seen[acc] {
+			return fmt.Errorf("duplicate axelar gmp account %q", acc)
+		}
+		seen[acc] = true
+	}
+
+	if len(p.AxelarChannels) > 0 && p.AxelarChainId == "" {
+		return fmt.Errorf("axelar chain id is required when axelar channels are configured")
+	}
+
+	seenChannels := make(map[AxelarChannel]bool, len(p.AxelarChannels))
+	for _, ch := range p.AxelarChannels {
+		if err := host.PortIdentifierValidator(ch.PortId); err != nil {
+			return fmt.Errorf("invalid axelar channel port %q: %w", ch.PortId, err)
+		}
+		if err := host.ChannelIdentifierValidator(ch.ChannelId); err != nil {
+			return fmt.Errorf("invalid axelar channel id %q: %w", ch.ChannelId, err)
+		}
+		if seenChannels[ch] {
+			return fmt.Errorf("duplicate axelar channel %s/%s", ch.PortId, ch.ChannelId)
+		}
+		seenChannels[ch] = true
+	}
+
+	if p.OutboundChannelId != "" && !p.IsAxelarChannel(transfertypes.PortID, p.OutboundChannelId) {
+		return fmt.Errorf("outbound channel %s is not a configured axelar channel", p.OutboundChannelId)
+	}
+	if p.ReplayRetentionBlocks == 0 {
+		return fmt.Errorf("replay retention blocks must be positive")
+	}
+	if p.ReplayPruneLimit == 0 {
+		return fmt.Errorf("replay prune limit must be positive")
+	}
+	if p.FailedRefundLimit == 0 {
+		return fmt.Errorf("failed refund limit must be positive")
+	}
+	if p.ExpressClaimRetentionBlocks == 0 {
+		return fmt.Errorf("express claim retention blocks must be positive")
+	}
+	if p.OutboundTimeout <= 0 {
+		return fmt.Errorf("outbound timeout must be positive, got %s", p.OutboundTimeout)
+	}
+	if _, ok := FailurePolicy_name[int32(p.FailurePolicy)]; !ok {
+		return fmt.Errorf("invalid failure policy %d", p.FailurePolicy)
+	}
+	if p.RecoveryAddress != "" {
+		if _, err := sdk.AccAddressFromBech32(p.RecoveryAddress); err != nil {
+			return fmt.Errorf("invalid recovery address %q: %w", p.RecoveryAddress, err)
+		}
+	} else if p.FailurePolicy == FailurePolicyRecoveryEscrow {
+		return fmt.Errorf("recovery address is required by the recovery escrow failure policy")
+	}
+	if p.MaxHandlerGas == 0 {
+		return fmt.Errorf("max handler gas must be positive")
+	}
+	if p.MaxMemoSize == 0 {
+		return fmt.Errorf("max memo size must be positive")
+	}
+	if p.MaxPayloadSize == 0 || p.MaxPayloadSize > p.MaxMemoSize {
+		return fmt.Errorf("max payload size must be positive and at most the max memo size, got %d", p.MaxPayloadSize)
+	}
+
+	seenExecutors := make(map[string]bool, len(p.ExpressExecutors))
+	for _, executor := range p.ExpressExecutors {
+		if _, err := sdk.AccAddressFromBech32(executor); err != nil {
+			return fmt.Errorf("invalid express executor %q: %w", executor, err)
+		}
+		if seenExecutors[executor] {
+			return fmt.Errorf("duplicate express executor %q", executor)
+		}
+		seenExecutors[executor] = true
+	}
+	return nil
+}
+
+// IsAxelarGMPAccount reports whether the address is one of the trusted Axelar GMP accounts.
+func (p Params) IsAxelarGMPAccount(addr string) bool {
+	for _, acc := range p.AxelarGmpAccounts {
+		if acc == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpressExecutor reports whether the address is one of the registered express executors.
+func (p Params) IsExpressExecutor(addr string) bool {
+	for _, executor := range p.ExpressExecutors {
+		if executor == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAxelarChannel reports whether the (port, channel) pair is configured as an Axelar channel.
+func (p Params) IsAxelarChannel(portID, channelID string) bool {
+	for _, ch := range p.AxelarChannels {
+		if ch.PortId == portID && ch.ChannelId == channelID {
+			return true
+		}
+	}
+	return false
+}
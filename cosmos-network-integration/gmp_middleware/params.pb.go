@@ -0,0 +1,1315 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: gmp/v1/params.proto
+
+package gmp_middleware
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// FailurePolicy defines how the middleware reacts to a failed GMP handler.
+type FailurePolicy int32
+
+const (
+	// FAILURE_POLICY_UNSPECIFIED behaves like FAILURE_POLICY_ERROR_ACK.
+	FailurePolicyUnspecified FailurePolicy = 0
+	// FAILURE_POLICY_ERROR_ACK returns an error acknowledgement, so the tokens go back to Axelar.
+	FailurePolicyErrorAck FailurePolicy = 1
+	// FAILURE_POLICY_RECOVERY_ESCROW keeps the tokens in a recovery escrow that the
+	// recovery_address param can claim. Messages without tokens get an error ack.
+	FailurePolicyRecoveryEscrow FailurePolicy = 2
+	// FAILURE_POLICY_STORE_AND_RETRY accepts the packet and stores the failed message with its
+	// tokens in module escrow, so anyone can retry it with MsgRetryGeneralMessage. Messages
+	// that are not retried in time are refunded to their source address through Axelar.
+	FailurePolicyStoreAndRetry FailurePolicy = 3
+	// FAILURE_POLICY_REFUND_TO_SOURCE accepts the packet and sends the tokens back to the
+	// message's source address through Axelar as an outbound TypeSendToken message. Messages
+	// without tokens get an error ack.
+	FailurePolicyRefundToSource FailurePolicy = 4
+)
+
+var FailurePolicy_name = map[int32]string{
+	0: "FAILURE_POLICY_UNSPECIFIED",
+	1: "FAILURE_POLICY_ERROR_ACK",
+	2: "FAILURE_POLICY_RECOVERY_ESCROW",
+	3: "FAILURE_POLICY_STORE_AND_RETRY",
+	4: "FAILURE_POLICY_REFUND_TO_SOURCE",
+}
+
+var FailurePolicy_value = map[string]int32{
+	"FAILURE_POLICY_UNSPECIFIED":      0,
+	"FAILURE_POLICY_ERROR_ACK":        1,
+	"FAILURE_POLICY_RECOVERY_ESCROW":  2,
+	"FAILURE_POLICY_STORE_AND_RETRY":  3,
+	"FAILURE_POLICY_REFUND_TO_SOURCE": 4,
+}
+
+func (x FailurePolicy) String() string {
+	return proto.EnumName(FailurePolicy_name, int32(x))
+}
+
+func (FailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bea6e3059278238b, []int{0}
+}
+
+// Params defines the governance-managed parameters of the gmp module.
+type Params struct {
+	// axelar_gmp_accounts are the Axelar accounts trusted to deliver GMP packets.
+	// ICS-20 packets from any other sender are treated as plain transfers.
+	// Outbound GMP transfers are addressed to the first account in the list.
+	AxelarGmpAccounts []string `protobuf:"bytes,1,rep,name=axelar_gmp_accounts,json=axelarGmpAccounts,proto3" json:"axelar_gmp_accounts,omitempty" yaml:"axelar_gmp_accounts"`
+	// axelar_chain_id is the chain ID of the Axelar network. The light client behind every
+	// configured channel must track this chain.
+	AxelarChainId string `protobuf:"bytes,2,opt,name=axelar_chain_id,json=axelarChainId,proto3" json:"axelar_chain_id,omitempty" yaml:"axelar_chain_id"`
+	// axelar_channels are the local (port, channel) pairs on which GMP memos are accepted.
+	AxelarChannels []AxelarChannel `protobuf:"bytes,3,rep,name=axelar_channels,json=axelarChannels,proto3" json:"axelar_channels" yaml:"axelar_channels"`
+	// outbound_channel_id is the transfer channel used to send GMP messages to Axelar.
+	// It must be one of the configured axelar_channels.
+	OutboundChannelId string `protobuf:"bytes,4,opt,name=outbound_channel_id,json=outboundChannelId,proto3" json:"outbound_channel_id,omitempty" yaml:"outbound_channel_id"`
+	// outbound_timeout is added to the block time to compute the timeout of outbound packets.
+	OutboundTimeout time.Duration `protobuf:"bytes,5,opt,name=outbound_timeout,json=outboundTimeout,proto3,stdduration" json:"outbound_timeout" yaml:"outbound_timeout"`
+	// replay_retention_blocks is the number of blocks a processed message is remembered for
+	// replay protection. It must be positive.
+	ReplayRetentionBlocks uint64 `protobuf:"varint,6,opt,name=replay_retention_blocks,json=replayRetentionBlocks,proto3" json:"replay_retention_blocks,omitempty" yaml:"replay_retention_blocks"`
+	// replay_prune_limit caps how many expired processed messages, and how many expired express
+	// claims, are pruned per block. It must be positive.
+	ReplayPruneLimit uint64 `protobuf:"varint,7,opt,name=replay_prune_limit,json=replayPruneLimit,proto3" json:"replay_prune_limit,omitempty" yaml:"replay_prune_limit"`
+	// failure_policy selects what happens to an inbound message whose handler fails.
+	FailurePolicy FailurePolicy `protobuf:"varint,8,opt,name=failure_policy,json=failurePolicy,proto3,enum=gmp.v1.FailurePolicy" json:"failure_policy,omitempty" yaml:"failure_policy"`
+	// max_handler_gas is the gas limit of every GMP handler execution.
+	MaxHandlerGas uint64 `protobuf:"varint,9,opt,name=max_handler_gas,json=maxHandlerGas,proto3" json:"max_handler_gas,omitempty" yaml:"max_handler_gas"`
+	// express_executors are the local accounts allowed to execute inbound messages ahead of their
+	// packet with MsgExpressExecute, and to be reimbursed once the packet arrives.
+	ExpressExecutors []string `protobuf:"bytes,10,rep,name=express_executors,json=expressExecutors,proto3" json:"express_executors,omitempty" yaml:"express_executors"`
+	// failed_message_retention_blocks is the number of blocks a message stored by the
+	// FAILURE_POLICY_STORE_AND_RETRY policy can be retried before its tokens are refunded to
+	// the source address. Zero keeps failed messages until they are retried.
+	FailedMessageRetentionBlocks uint64 `protobuf:"varint,11,opt,name=failed_message_retention_blocks,json=failedMessageRetentionBlocks,proto3" json:"failed_message_retention_blocks,omitempty" yaml:"failed_message_retention_blocks"`
+	// max_memo_size is the largest GMP memo, in bytes, that is decoded. Larger memos are rejected.
+	MaxMemoSize uint64 `protobuf:"varint,12,opt,name=max_memo_size,json=maxMemoSize,proto3" json:"max_memo_size,omitempty" yaml:"max_memo_size"`
+	// max_payload_size is the largest decoded GMP payload, in bytes, that is handed to a handler.
+	MaxPayloadSize uint64 `protobuf:"varint,13,opt,name=max_payload_size,json=maxPayloadSize,proto3" json:"max_payload_size,omitempty" yaml:"max_payload_size"`
+	// recovery_address is the local account that may claim the tokens kept in recovery escrow by
+	// the FAILURE_POLICY_RECOVERY_ESCROW policy. Axelar memos name no recovery address, so it is
+	// set by governance and required by that policy.
+	RecoveryAddress string `protobuf:"bytes,14,opt,name=recovery_address,json=recoveryAddress,proto3" json:"recovery_address,omitempty" yaml:"recovery_address"`
+	// failed_refund_limit caps how many expired failed messages are refunded per block, each
+	// with its own refund packet. It must be positive.
+	FailedRefundLimit uint64 `protobuf:"varint,15,opt,name=failed_refund_limit,json=failedRefundLimit,proto3" json:"failed_refund_limit,omitempty" yaml:"failed_refund_limit"`
+	// express_claim_retention_blocks is the number of blocks an express executor waits for the
+	// packet that reimburses it. Unsettled claims are pruned after that and the executor is not
+	// repaid. It must be positive.
+	ExpressClaimRetentionBlocks uint64 `protobuf:"varint,16,opt,name=express_claim_retention_blocks,json=expressClaimRetentionBlocks,proto3" json:"express_claim_retention_blocks,omitempty" yaml:"express_claim_retention_blocks"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bea6e3059278238b, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetAxelarGmpAccounts() []string {
+	if m != nil {
+		return m.AxelarGmpAccounts
+	}
+	return nil
+}
+
+func (m *Params) GetAxelarChainId() string {
+	if m != nil {
+		return m.AxelarChainId
+	}
+	return ""
+}
+
+func (m *Params) GetAxelarChannels() []AxelarChannel {
+	if m != nil {
+		return m.AxelarChannels
+	}
+	return nil
+}
+
+func (m *Params) GetOutboundChannelId() string {
+	if m != nil {
+		return m.OutboundChannelId
+	}
+	return ""
+}
+
+func (m *Params) GetOutboundTimeout() time.Duration {
+	if m != nil {
+		return m.OutboundTimeout
+	}
+	return 0
+}
+
+func (m *Params) GetReplayRetentionBlocks() uint64 {
+	if m != nil {
+		return m.ReplayRetentionBlocks
+	}
+	return 0
+}
+
+func (m *Params) GetReplayPruneLimit() uint64 {
+	if m != nil {
+		return m.ReplayPruneLimit
+	}
+	return 0
+}
+
+func (m *Params) GetFailurePolicy() FailurePolicy {
+	if m != nil {
+		return m.FailurePolicy
+	}
+	return FailurePolicyUnspecified
+}
+
+func (m *Params) GetMaxHandlerGas() uint64 {
+	if m != nil {
+		return m.MaxHandlerGas
+	}
+	return 0
+}
+
+func (m *Params) GetExpressExecutors() []string {
+	if m != nil {
+		return m.ExpressExecutors
+	}
+	return nil
+}
+
+func (m *Params) GetFailedMessageRetentionBlocks() uint64 {
+	if m != nil {
+		return m.FailedMessageRetentionBlocks
+	}
+	return 0
+}
+
+func (m *Params) GetMaxMemoSize() uint64 {
+	if m != nil {
+		return m.MaxMemoSize
+	}
+	return 0
+}
+
+func (m *Params) GetMaxPayloadSize() uint64 {
+	if m != nil {
+		return m.MaxPayloadSize
+	}
+	return 0
+}
+
+func (m *Params) GetRecoveryAddress() string {
+	if m != nil {
+		return m.RecoveryAddress
+	}
+	return ""
+}
+
+func (m *Params) GetFailedRefundLimit() uint64 {
+	if m != nil {
+		return m.FailedRefundLimit
+	}
+	return 0
+}
+
+func (m *Params) GetExpressClaimRetentionBlocks() uint64 {
+	if m != nil {
+		return m.ExpressClaimRetentionBlocks
+	}
+	return 0
+}
+
+// AxelarChannel identifies a local channel end that is connected to Axelar.
+type AxelarChannel struct {
+	PortId    string `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+}
+
+func (m *AxelarChannel) Reset()         { *m = AxelarChannel{} }
+func (m *AxelarChannel) String() string { return proto.CompactTextString(m) }
+func (*AxelarChannel) ProtoMessage()    {}
+func (*AxelarChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bea6e3059278238b, []int{1}
+}
+func (m *AxelarChannel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AxelarChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AxelarChannel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AxelarChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AxelarChannel.Merge(m, src)
+}
+func (m *AxelarChannel) XXX_Size() int {
+	return m.Size()
+}
+func (m *AxelarChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_AxelarChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AxelarChannel proto.InternalMessageInfo
+
+func (m *AxelarChannel) GetPortId() string {
+	if m != nil {
+		return m.PortId
+	}
+	return ""
+}
+
+func (m *AxelarChannel) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("gmp.v1.FailurePolicy", FailurePolicy_name, FailurePolicy_value)
+	proto.RegisterType((*Params)(nil), "gmp.v1.Params")
+	proto.RegisterType((*AxelarChannel)(nil), "gmp.v1.AxelarChannel")
+}
+
+func init() { proto.RegisterFile("gmp/v1/params.proto", fileDescriptor_bea6e3059278238b) }
+
+var fileDescriptor_bea6e3059278238b = []byte{
+	// 1024 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0xc1, 0x4e, 0xe3, 0x46,
+	0x18, 0x8e, 0x81, 0xb2, 0x65, 0x68, 0xc0, 0x78, 0xa1, 0x18, 0xc3, 0xda, 0x96, 0xab, 0x56, 0xe9,
+	0x4a, 0x24, 0xda, 0xdd, 0x43, 0xa5, 0xd5, 0x5e, 0x92, 0x60, 0x68, 0xb4, 0x2c, 0x89, 0x26, 0xa1,
+	0x2b, 0xf6, 0xb0, 0xa3, 0xc1, 0x1e, 0xbc, 0x16, 0xb6, 0xc7, 0x1d, 0x3b, 0x90, 0xec, 0x13, 0x54,
+	0x9c, 0x7a, 0xec, 0x85, 0x53, 0x5f, 0xa1, 0x0f, 0xb1, 0xc7, 0x55, 0x4f, 0x95, 0x2a, 0xa5, 0x2b,
+	0x78, 0x83, 0x3c, 0x41, 0x65, 0x8f, 0xbd, 0xc4, 0x21, 0xed, 0xcd, 0xfe, 0xfe, 0xef, 0xff, 0xe6,
+	0x9f, 0xf9, 0xfe, 0xf9, 0x07, 0x3c, 0x74, 0xfc, 0xb0, 0x76, 0xf1, 0xa4, 0x16, 0x62, 0x86, 0xfd,
+	0xa8, 0x1a, 0x32, 0x1a, 0x53, 0x69, 0xd1, 0xf1, 0xc3, 0xea, 0xc5, 0x13, 0x65, 0xcb, 0xa2, 0x91,
+	0x4f, 0x23, 0x94, 0xa2, 0x35, 0xfe, 0xc3, 0x29, 0xca, 0xba, 0x43, 0x1d, 0xca, 0xf1, 0xe4, 0x2b,
+	0x43, 0x55, 0x87, 0x52, 0xc7, 0x23, 0xb5, 0xf4, 0xef, 0xb4, 0x7f, 0x56, 0xb3, 0xfb, 0x0c, 0xc7,
+	0x2e, 0x0d, 0x78, 0xdc, 0xf8, 0x04, 0xc0, 0x62, 0x27, 0x5d, 0x49, 0x3a, 0x02, 0x0f, 0xf1, 0x80,
+	0x78, 0x98, 0x21, 0xc7, 0x0f, 0x11, 0xb6, 0x2c, 0xda, 0x0f, 0xe2, 0x48, 0x16, 0xf4, 0xf9, 0xca,
+	0x52, 0x43, 0x1d, 0x8f, 0x34, 0x65, 0x88, 0x7d, 0xef, 0xb9, 0x31, 0x83, 0x64, 0xc0, 0x35, 0x8e,
+	0x1e, 0xf8, 0x61, 0x3d, 0xc3, 0xa4, 0x06, 0x58, 0xcd, 0xa8, 0xd6, 0x3b, 0xec, 0x06, 0xc8, 0xb5,
+	0xe5, 0x39, 0x5d, 0xa8, 0x2c, 0x35, 0x94, 0xf1, 0x48, 0xfb, 0xba, 0xa0, 0x95, 0x13, 0x0c, 0x58,
+	0xe6, 0x48, 0x33, 0x01, 0x5a, 0xb6, 0xf4, 0x76, 0x52, 0x23, 0x08, 0x88, 0x17, 0xc9, 0xf3, 0xfa,
+	0x7c, 0x65, 0xf9, 0xe9, 0x46, 0x95, 0x9f, 0x48, 0xb5, 0x9e, 0xf3, 0x93, 0x68, 0x43, 0xfd, 0x30,
+	0xd2, 0x4a, 0xb3, 0xe4, 0xd3, 0x5c, 0x03, 0xae, 0xe0, 0x49, 0x7a, 0xba, 0x67, 0xda, 0x8f, 0x4f,
+	0x69, 0x3f, 0xb0, 0x73, 0x56, 0x52, 0xe7, 0x82, 0x2e, 0x14, 0xf7, 0x3c, 0x83, 0x64, 0xc0, 0xb5,
+	0x1c, 0xcd, 0xe4, 0x5a, 0xb6, 0xe4, 0x02, 0xf1, 0x33, 0x35, 0x76, 0x7d, 0x42, 0xfb, 0xb1, 0xfc,
+	0x85, 0x2e, 0x54, 0x96, 0x9f, 0x6e, 0x55, 0xb9, 0x13, 0xd5, 0xdc, 0x89, 0xea, 0x5e, 0xe6, 0x44,
+	0xe3, 0x9b, 0xac, 0xe8, 0xcd, 0xa9, 0xb5, 0x32, 0x01, 0xe3, 0xb7, 0x7f, 0x34, 0x01, 0xae, 0xe6,
+	0x70, 0x8f, 0xa3, 0xd2, 0x1b, 0xb0, 0xc9, 0x48, 0xe8, 0xe1, 0x21, 0x62, 0x24, 0x26, 0x41, 0xa2,
+	0x84, 0x4e, 0x3d, 0x6a, 0x9d, 0x47, 0xf2, 0xa2, 0x2e, 0x54, 0x16, 0x1a, 0xc6, 0x78, 0xa4, 0xa9,
+	0x5c, 0xf2, 0x3f, 0x88, 0x06, 0xdc, 0xe0, 0x11, 0x98, 0x07, 0x1a, 0x29, 0x2e, 0xbd, 0x04, 0x52,
+	0x96, 0x12, 0xb2, 0x7e, 0x40, 0x90, 0xe7, 0xfa, 0x6e, 0x2c, 0x3f, 0x48, 0x65, 0x1f, 0x8d, 0x47,
+	0xda, 0x56, 0x41, 0x76, 0x82, 0x63, 0x40, 0x91, 0x83, 0x9d, 0x04, 0x3b, 0x4c, 0x20, 0xe9, 0x35,
+	0x58, 0x39, 0xc3, 0xae, 0xd7, 0x67, 0x04, 0x85, 0xd4, 0x73, 0xad, 0xa1, 0xfc, 0xa5, 0x2e, 0x54,
+	0x56, 0xee, 0x2c, 0xdc, 0xe7, 0xd1, 0x4e, 0x1a, 0x6c, 0x6c, 0x8d, 0x47, 0xda, 0x06, 0xd7, 0x2f,
+	0xa6, 0x19, 0xb0, 0x7c, 0x36, 0xc9, 0x4c, 0x1a, 0xcc, 0xc7, 0x03, 0xf4, 0x0e, 0x07, 0xb6, 0x47,
+	0x18, 0x72, 0x70, 0x24, 0x2f, 0xa5, 0x25, 0x4e, 0x34, 0xd8, 0x14, 0xc1, 0x80, 0x65, 0x1f, 0x0f,
+	0x7e, 0xe4, 0xc0, 0x01, 0x8e, 0xa4, 0x16, 0x58, 0x23, 0x83, 0x90, 0x91, 0x28, 0x42, 0x64, 0x40,
+	0xac, 0x7e, 0x4c, 0x59, 0x24, 0x83, 0xb4, 0xe5, 0x77, 0xc6, 0x23, 0x4d, 0xe6, 0x2a, 0xf7, 0x28,
+	0x06, 0x14, 0x33, 0xcc, 0xcc, 0x21, 0xe9, 0x67, 0xa0, 0x25, 0xf5, 0x11, 0x1b, 0xf9, 0x24, 0x8a,
+	0xb0, 0x43, 0xee, 0x1b, 0xb3, 0x9c, 0x96, 0xf7, 0x78, 0x3c, 0xd2, 0xbe, 0xbb, 0xdb, 0xe1, 0xff,
+	0x24, 0x18, 0x70, 0x87, 0x33, 0x5e, 0x71, 0xc2, 0xb4, 0x4f, 0x2f, 0x40, 0xb2, 0x1d, 0xe4, 0x13,
+	0x9f, 0xa2, 0xc8, 0x7d, 0x4f, 0xe4, 0xaf, 0xd2, 0x05, 0xe4, 0xf1, 0x48, 0x5b, 0xbf, 0xdb, 0xff,
+	0xe7, 0xb0, 0x01, 0x97, 0x7d, 0x3c, 0x78, 0x45, 0x7c, 0xda, 0x75, 0xdf, 0x13, 0xc9, 0x04, 0x62,
+	0x12, 0x0e, 0xf1, 0xd0, 0xa3, 0xd8, 0xe6, 0x02, 0xe5, 0x54, 0x60, 0xfb, 0xae, 0x1b, 0xa7, 0x19,
+	0x06, 0x5c, 0xf1, 0xf1, 0xa0, 0xc3, 0x91, 0x54, 0xe6, 0x2d, 0x10, 0x19, 0xb1, 0xe8, 0x05, 0x61,
+	0x43, 0x84, 0x6d, 0x3b, 0x39, 0x14, 0x79, 0x25, 0xbd, 0x40, 0xcf, 0xee, 0x64, 0xa6, 0x19, 0xc6,
+	0x9f, 0x7f, 0xec, 0xae, 0x67, 0xf3, 0xab, 0xce, 0xa1, 0x6e, 0xcc, 0xdc, 0xc0, 0x81, 0xab, 0x39,
+	0x35, 0x83, 0x93, 0x3b, 0x9a, 0x1d, 0x13, 0x23, 0x67, 0xc9, 0xbd, 0xe0, 0xdd, 0xb8, 0x9a, 0x56,
+	0x3a, 0x71, 0x47, 0x67, 0x90, 0x0c, 0xb8, 0xc6, 0x51, 0x98, 0x82, 0xbc, 0x1f, 0x03, 0xa0, 0xe6,
+	0x7e, 0x5a, 0x1e, 0x76, 0xfd, 0xfb, 0x36, 0x89, 0xa9, 0xf4, 0xf7, 0xe3, 0x91, 0xf6, 0x6d, 0xd1,
+	0xff, 0xd9, 0x7c, 0x03, 0x6e, 0x67, 0x84, 0x66, 0x12, 0x9f, 0x32, 0xc9, 0x38, 0x00, 0xe5, 0xc2,
+	0x90, 0x92, 0x36, 0xc1, 0x83, 0x90, 0xb2, 0x38, 0x19, 0x34, 0x42, 0x72, 0x4e, 0x70, 0x31, 0xf9,
+	0x6d, 0xd9, 0xd2, 0x23, 0x00, 0x26, 0x86, 0x50, 0x3a, 0x2c, 0xe1, 0x92, 0x95, 0x0f, 0x97, 0xc7,
+	0x7f, 0xcf, 0x81, 0x72, 0xe1, 0xae, 0x48, 0x2f, 0x80, 0xb2, 0x5f, 0x6f, 0x1d, 0x1e, 0x43, 0x13,
+	0x75, 0xda, 0x87, 0xad, 0xe6, 0x09, 0x3a, 0x3e, 0xea, 0x76, 0xcc, 0x66, 0x6b, 0xbf, 0x65, 0xee,
+	0x89, 0x25, 0x65, 0xe7, 0xea, 0x5a, 0x97, 0x0b, 0x29, 0xc7, 0x41, 0x14, 0x12, 0xcb, 0x3d, 0x73,
+	0x89, 0x2d, 0xfd, 0x00, 0xe4, 0xa9, 0x6c, 0x13, 0xc2, 0x36, 0x44, 0xf5, 0xe6, 0x4b, 0x51, 0x50,
+	0xb6, 0xae, 0xae, 0xf5, 0x8d, 0x42, 0xae, 0xc9, 0x18, 0x65, 0x75, 0xeb, 0x5c, 0x6a, 0x02, 0x75,
+	0x2a, 0x11, 0x9a, 0xcd, 0xf6, 0x4f, 0x26, 0x3c, 0x41, 0x66, 0xb7, 0x09, 0xdb, 0xaf, 0xc5, 0x39,
+	0x45, 0xbb, 0xba, 0xd6, 0xb7, 0x0b, 0xe9, 0x30, 0xf3, 0xd5, 0x8c, 0x2c, 0x46, 0x2f, 0xa5, 0xc6,
+	0x3d, 0x91, 0x6e, 0xaf, 0x0d, 0x4d, 0x54, 0x3f, 0xda, 0x43, 0xd0, 0xec, 0xc1, 0x13, 0x71, 0x5e,
+	0x51, 0xaf, 0xae, 0x75, 0xa5, 0x20, 0xd2, 0x8d, 0x29, 0x23, 0xf5, 0xc0, 0x86, 0x24, 0x66, 0x43,
+	0x69, 0x0f, 0x68, 0xf7, 0x0a, 0xd9, 0x3f, 0x3e, 0xda, 0x43, 0xbd, 0x36, 0xea, 0xb6, 0x8f, 0x61,
+	0xd3, 0x14, 0x17, 0x66, 0x56, 0x92, 0x74, 0x43, 0x8f, 0x76, 0x69, 0x9f, 0x59, 0x44, 0x59, 0xf8,
+	0xe5, 0x77, 0xb5, 0xd4, 0xe8, 0x7d, 0xb8, 0x51, 0x85, 0x8f, 0x37, 0xaa, 0xf0, 0xe9, 0x46, 0x15,
+	0x7e, 0xbd, 0x55, 0x4b, 0x1f, 0x6f, 0xd5, 0xd2, 0x5f, 0xb7, 0x6a, 0xe9, 0xcd, 0x73, 0xfe, 0x68,
+	0xec, 0xf2, 0x76, 0xdd, 0x75, 0xf2, 0x87, 0x77, 0x37, 0x20, 0xf1, 0x25, 0x65, 0xe7, 0xbb, 0x6e,
+	0x10, 0x13, 0x87, 0x0f, 0xf3, 0x5a, 0xf2, 0x2c, 0xfa, 0xae, 0x6d, 0x7b, 0xe4, 0x12, 0x33, 0x72,
+	0xba, 0x98, 0x8e, 0xfb, 0x67, 0xff, 0x0e, 0x00, 0x13, 0x8e, 0xac, 0x96, 0xd6, 0x07, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpressClaimRetentionBlocks != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ExpressClaimRetentionBlocks))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.FailedRefundLimit != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.FailedRefundLimit))
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.RecoveryAddress) > 0 {
+		i -= len(m.RecoveryAddress)
+		copy(dAtA[i:], m.RecoveryAddress)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.RecoveryAddress)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.MaxPayloadSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxPayloadSize))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.MaxMemoSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxMemoSize))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.FailedMessageRetentionBlocks != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.FailedMessageRetentionBlocks))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.ExpressExecutors) > 0 {
+		for iNdEx := len(m.ExpressExecutors) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExpressExecutors[iNdEx])
+			copy(dAtA[i:], m.ExpressExecutors[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.ExpressExecutors[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if m.MaxHandlerGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxHandlerGas))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.FailurePolicy != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.FailurePolicy))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ReplayPruneLimit != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ReplayPruneLimit))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ReplayRetentionBlocks != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ReplayRetentionBlocks))
+		i--
+		dAtA[i] = 0x30
+	}
+	n1, err1 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.OutboundTimeout, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.OutboundTimeout):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintParams(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.OutboundChannelId) > 0 {
+		i -= len(m.OutboundChannelId)
+		copy(dAtA[i:], m.OutboundChannelId)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.OutboundChannelId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.AxelarChannels) > 0 {
+		for iNdEx := len(m.AxelarChannels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AxelarChannels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintParams(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.AxelarChainId) > 0 {
+		i -= len(m.AxelarChainId)
+		copy(dAtA[i:], m.AxelarChainId)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.AxelarChainId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AxelarGmpAccounts) > 0 {
+		for iNdEx := len(m.AxelarGmpAccounts) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AxelarGmpAccounts[iNdEx])
+			copy(dAtA[i:], m.AxelarGmpAccounts[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.AxelarGmpAccounts[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AxelarChannel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AxelarChannel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AxelarChannel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChannelId) > 0 {
+		i -= len(m.ChannelId)
+		copy(dAtA[i:], m.ChannelId)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.ChannelId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PortId) > 0 {
+		i -= len(m.PortId)
+		copy(dAtA[i:], m.PortId)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.PortId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AxelarGmpAccounts) > 0 {
+		for _, s := range m.AxelarGmpAccounts {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	l = len(m.AxelarChainId)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if len(m.AxelarChannels) > 0 {
+		for _, e := range m.AxelarChannels {
+			l = e.Size()
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	l = len(m.OutboundChannelId)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.OutboundTimeout)
+	n += 1 + l + sovParams(uint64(l))
+	if m.ReplayRetentionBlocks != 0 {
+		n += 1 + sovParams(uint64(m.ReplayRetentionBlocks))
+	}
+	if m.ReplayPruneLimit != 0 {
+		n += 1 + sovParams(uint64(m.ReplayPruneLimit))
+	}
+	if m.FailurePolicy != 0 {
+		n += 1 + sovParams(uint64(m.FailurePolicy))
+	}
+	if m.MaxHandlerGas != 0 {
+		n += 1 + sovParams(uint64(m.MaxHandlerGas))
+	}
+	if len(m.ExpressExecutors) > 0 {
+		for _, s := range m.ExpressExecutors {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	if m.FailedMessageRetentionBlocks != 0 {
+		n += 1 + sovParams(uint64(m.FailedMessageRetentionBlocks))
+	}
+	if m.MaxMemoSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxMemoSize))
+	}
+	if m.MaxPayloadSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxPayloadSize))
+	}
+	l = len(m.RecoveryAddress)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.FailedRefundLimit != 0 {
+		n += 1 + sovParams(uint64(m.FailedRefundLimit))
+	}
+	if m.ExpressClaimRetentionBlocks != 0 {
+		n += 2 + sovParams(uint64(m.ExpressClaimRetentionBlocks))
+	}
+	return n
+}
+
+func (m *AxelarChannel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PortId)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	l = len(m.ChannelId)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	return n
+}
+
+func sovParams(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozParams(x uint64) (n int) {
+	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AxelarGmpAccounts", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AxelarGmpAccounts = append(m.AxelarGmpAccounts, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AxelarChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AxelarChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AxelarChannels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AxelarChannels = append(m.AxelarChannels, AxelarChannel{})
+			if err := m.AxelarChannels[len(m.AxelarChannels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutboundChannelId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutboundChannelId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutboundTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.OutboundTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplayRetentionBlocks", wireType)
+			}
+			m.ReplayRetentionBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplayRetentionBlocks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplayPruneLimit", wireType)
+			}
+			m.ReplayPruneLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplayPruneLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailurePolicy", wireType)
+			}
+			m.FailurePolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailurePolicy |= FailurePolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxHandlerGas", wireType)
+			}
+			m.MaxHandlerGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxHandlerGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpressExecutors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpressExecutors = append(m.ExpressExecutors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedMessageRetentionBlocks", wireType)
+			}
+			m.FailedMessageRetentionBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailedMessageRetentionBlocks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMemoSize", wireType)
+			}
+			m.MaxMemoSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMemoSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPayloadSize", wireType)
+			}
+			m.MaxPayloadSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPayloadSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecoveryAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecoveryAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedRefundLimit", wireType)
+			}
+			m.FailedRefundLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailedRefundLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpressClaimRetentionBlocks", wireType)
+			}
+			m.ExpressClaimRetentionBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpressClaimRetentionBlocks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AxelarChannel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AxelarChannel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AxelarChannel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PortId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PortId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipParams(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthParams
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupParams
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthParams
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthParams        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowParams          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupParams = fmt.Errorf("proto: unexpected end of group")
+)
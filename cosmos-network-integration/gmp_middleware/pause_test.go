@@ -0,0 +1,114 @@
+package gmp_middleware
+
+import (
+	"strings"
+	"testing"
+
+	circuittypes "cosmossdk.io/x/circuit/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestOnRecvPacketRejectsPausedMessages(t *testing.T) {
+	for name, pause := range map[string]Pause{
+		"all":            {Scope: PauseScopeAll},
+		"source chain":   {Scope: PauseScopeSourceChain, SourceChain: testSourceChain},
+		"source address": {Scope: PauseScopeSourceAddress, SourceChain: testSourceChain, SourceAddress: testSourceAddress},
+		"message type":   {Scope: PauseScopeMessageType, MessageType: TypeGeneralMessageWithToken},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFixture(t)
+			receiver := testAddr("receiver").String()
+			f.keeper.SetPause(f.ctx, pause)
+
+			require.False(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+			require.Empty(t, f.handler.calls)
+
+			require.True(t, f.keeper.DeletePause(f.ctx, pause))
+			require.True(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+			require.Len(t, f.handler.calls, 1)
+		})
+	}
+}
+
+func TestOnRecvPacketPauseLeavesOtherTraffic(t *testing.T) {
+	f := newTestFixture(t)
+	f.keeper.SetPause(f.ctx, Pause{Scope: PauseScopeSourceChain, SourceChain: "Avalanche"})
+	f.keeper.SetPause(f.ctx, Pause{Scope: PauseScopeMessageType, MessageType: TypeGeneralMessage})
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Len(t, f.handler.calls, 1)
+}
+
+func TestPauseMatchesSourceChainInAnyCase(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+	pause := Pause{Scope: PauseScopeSourceAddress, SourceChain: strings.ToUpper(testSourceChain), SourceAddress: testSourceAddress}
+	f.keeper.SetPause(f.ctx, pause)
+	require.Equal(t, strings.ToLower(testSourceChain), f.keeper.GetAllPauses(f.ctx)[0].SourceChain)
+
+	msg := testMessage()
+	msg.SourceChain = strings.ToLower(testSourceChain)
+	require.False(t, f.recvPacket(t, receiver, 100, msg).Success())
+	require.False(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	require.Empty(t, f.handler.calls)
+
+	pause.SourceChain = testSourceChain
+	require.True(t, f.keeper.DeletePause(f.ctx, pause))
+	require.Empty(t, f.keeper.GetAllPauses(f.ctx))
+}
+
+func TestMsgPauseAuthorization(t *testing.T) {
+	f := newTestFixture(t)
+	server := NewMsgServerImpl(f.keeper)
+	breaker, someone := testAddr("breaker"), testAddr("someone")
+	f.circuit.permissions[breaker.String()] = circuittypes.Permissions{
+		Level:         circuittypes.Permissions_LEVEL_SOME_MSGS,
+		LimitTypeUrls: []string{sdk.MsgTypeURL(&MsgPause{})},
+	}
+
+	msg := &MsgPause{Authority: someone.String(), Scope: PauseScopeSourceChain, SourceChain: testSourceChain}
+	_, err := server.Pause(f.ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	msg.Authority = breaker.String()
+	_, err = server.Pause(f.ctx, msg)
+	require.NoError(t, err)
+	require.ErrorIs(t, f.keeper.CheckPaused(f.ctx, testMessage()), ErrPaused)
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventPaused"))
+
+	// the breaker may only pause, so unpausing takes the authority
+	unpause := &MsgUnpause{Authority: breaker.String(), Scope: PauseScopeSourceChain, SourceChain: testSourceChain}
+	_, err = server.Unpause(f.ctx, unpause)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	unpause.Authority = testAuthority.String()
+	_, err = server.Unpause(f.ctx, unpause)
+	require.NoError(t, err)
+	require.NoError(t, f.keeper.CheckPaused(f.ctx, testMessage()))
+
+	_, err = server.Unpause(f.ctx, unpause)
+	require.ErrorIs(t, err, ErrNotPaused)
+}
+
+func TestPauseValidate(t *testing.T) {
+	require.NoError(t, Pause{Scope: PauseScopeAll}.Validate())
+	require.NoError(t, Pause{Scope: PauseScopeMessageType, MessageType: TypeSendToken}.Validate())
+	require.NoError(t, Pause{Scope: PauseScopeSourceChain, SourceChain: "ethereum"}.Validate())
+
+	for name, pause := range map[string]Pause{
+		"unspecified scope":         {},
+		"all with source chain":     {Scope: PauseScopeAll, SourceChain: "ethereum"},
+		"source chain missing":      {Scope: PauseScopeSourceChain},
+		"source chain not lower":    {Scope: PauseScopeSourceChain, SourceChain: "Ethereum"},
+		"source address missing":    {Scope: PauseScopeSourceAddress, SourceChain: "ethereum"},
+		"unrecognized message type": {Scope: PauseScopeMessageType, MessageType: TypeUnrecognized},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, pause.Validate())
+		})
+	}
+}
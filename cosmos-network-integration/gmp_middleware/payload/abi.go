@@ -0,0 +1,43 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// DecodeABI decodes a version 0 payload into values of the given ABI arguments.
+func DecodeABI(payload []byte, args abi.Arguments) ([]interface{}, error) {
+	bz, err := body(payload, VersionABI)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := args.Unpack(bz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return values, nil
+}
+
+// EncodeABI builds a version 0 payload from values of the given ABI arguments.
+func EncodeABI(args abi.Arguments, values ...interface{}) ([]byte, error) {
+	bz, err := args.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return Join(VersionABI, bz), nil
+}
+
+// Arguments builds ABI arguments from type names such as "string" or "address[]".
+func Arguments(types ...string) (abi.Arguments, error) {
+	args := make(abi.Arguments, 0, len(types))
+	for _, t := range types {
+		typ, err := abi.NewType(t, t, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: abi type %q: %s", ErrInvalidPayload, t, err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	return args, nil
+}
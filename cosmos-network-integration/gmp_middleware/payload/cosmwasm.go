@@ -0,0 +1,176 @@
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CosmWasmCall is the content of a version 1 payload. It describes a CosmWasm execute message
+// with ABI, as built by SendReceive._encodePayloadToCosmWasm on EVM:
+//
+//	abi.encode(string method, string[] argNames, string[] argTypes, bytes argValues)
+//
+// where argValues is the ABI encoding of the arguments with argTypes.
+type CosmWasmCall struct {
+	Method    string
+	ArgNames  []string
+	ArgTypes  []string
+	ArgValues []byte
+}
+
+// cosmWasmCallArgs are the ABI arguments of a version 1 payload.
+var cosmWasmCallArgs = mustArguments("string", "string[]", "string[]", "bytes")
+
+// DecodeCosmWasmABI decodes a version 1 payload.
+func DecodeCosmWasmABI(payload []byte) (CosmWasmCall, error) {
+	bz, err := body(payload, VersionCosmWasmABI)
+	if err != nil {
+		return CosmWasmCall{}, err
+	}
+
+	values, err := cosmWasmCallArgs.Unpack(bz)
+	if err != nil {
+		return CosmWasmCall{}, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+
+	call := CosmWasmCall{
+		Method:    values[0].(string),
+		ArgNames:  values[1].([]string),
+		ArgTypes:  values[2].([]string),
+		ArgValues: values[3].([]byte),
+	}
+	if err := call.Validate(); err != nil {
+		return CosmWasmCall{}, err
+	}
+	return call, nil
+}
+
+// EncodeCosmWasmABI builds a version 1 payload.
+func EncodeCosmWasmABI(call CosmWasmCall) ([]byte, error) {
+	if err := call.Validate(); err != nil {
+		return nil, err
+	}
+
+	bz, err := cosmWasmCallArgs.Pack(call.Method, call.ArgNames, call.ArgTypes, call.ArgValues)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return Join(VersionCosmWasmABI, bz), nil
+}
+
+// NewCosmWasmCall builds a CosmWasmCall from argument names, ABI types and values.
+func NewCosmWasmCall(method string, argNames, argTypes []string, values ...interface{}) (CosmWasmCall, error) {
+	args, err := Arguments(argTypes...)
+	if err != nil {
+		return CosmWasmCall{}, err
+	}
+
+	argValues, err := args.Pack(values...)
+	if err != nil {
+		return CosmWasmCall{}, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+
+	call := CosmWasmCall{
+		Method:    method,
+		ArgNames:  argNames,
+		ArgTypes:  argTypes,
+		ArgValues: argValues,
+	}
+	return call, call.Validate()
+}
+
+// Validate checks that the call has a method and one name per ABI type.
+func (c CosmWasmCall) Validate() error {
+	if c.Method == "" {
+		return fmt.Errorf("%w: method is required", ErrInvalidPayload)
+	}
+	if len(c.ArgNames) != len(c.ArgTypes) {
+		return fmt.Errorf("%w: %d argument names for %d types", ErrInvalidPayload, len(c.ArgNames), len(c.ArgTypes))
+	}
+	return nil
+}
+
+// Args decodes the argument values with their ABI types.
+func (c CosmWasmCall) Args() ([]interface{}, error) {
+	args, err := Arguments(c.ArgTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := args.Unpack(c.ArgValues)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return values, nil
+}
+
+// ExecuteMsg returns the CosmWasm execute message of the call, {"method": {"arg": value, ...}},
+// the same translation Axelar applies before handing a version 1 payload to ibc-hooks.
+// Integers that do not fit in 64 bits become decimal strings, addresses 0x prefixed hex strings
+// and bytes base64 strings, matching the CosmWasm Uint128, String and Binary types.
+func (c CosmWasmCall) ExecuteMsg() ([]byte, error) {
+	values, err := c.Args()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		name := c.ArgNames[i]
+		if _, ok := args[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate argument %q", ErrInvalidPayload, name)
+		}
+		args[name] = jsonValue(value)
+	}
+
+	bz, err := json.Marshal(map[string]interface{}{c.Method: args})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return bz, nil
+}
+
+// jsonValue converts a value unpacked by go-ethereum into its JSON representation.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		// Fixed-size bytes, e.g. bytes32.
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bz := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bz), rv)
+			return bz
+		}
+		fallthrough
+	case reflect.Slice:
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return elems
+	default:
+		return value
+	}
+}
+
+func mustArguments(types ...string) abi.Arguments {
+	args, err := Arguments(types...)
+	if err != nil {
+		panic(err)
+	}
+	return args
+}
@@ -0,0 +1,28 @@
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeJSON decodes a version 2 payload into v.
+func DecodeJSON(payload []byte, v interface{}) error {
+	bz, err := body(payload, VersionJSON)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return nil
+}
+
+// EncodeJSON builds a version 2 payload from v.
+func EncodeJSON(v interface{}) ([]byte, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return Join(VersionJSON, bz), nil
+}
@@ -0,0 +1,92 @@
+// Package payload encodes and decodes the versioned payloads that Axelar GMP carries between
+// EVM and Cosmos chains. Every payload starts with a 4-byte big-endian version:
+//
+//	0x00000000  ABI encoded arguments, decoded by the receiving handler
+//	0x00000001  CosmWasm call described with ABI: method, argument names, types and values
+//	0x00000002  JSON, e.g. a CosmWasm execute message
+package payload
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Version is the version prefix of a GMP payload.
+type Version uint32
+
+const (
+	// VersionABI is used for ABI encoded payloads, e.g. SendReceive.send on EVM.
+	VersionABI Version = 0
+	// VersionCosmWasmABI is used for CosmWasm calls encoded with Axelar's ABI schema.
+	VersionCosmWasmABI Version = 1
+	// VersionJSON is used for JSON payloads, e.g. CosmWasm execute messages.
+	VersionJSON Version = 2
+)
+
+// VersionLength is the length of the version prefix.
+const VersionLength = 4
+
+var (
+	// ErrPayloadTooShort is returned when a payload has no room for the version prefix.
+	ErrPayloadTooShort = errors.New("payload too short")
+	// ErrUnsupportedVersion is returned for version prefixes this package does not know.
+	ErrUnsupportedVersion = errors.New("unsupported payload version")
+	// ErrVersionMismatch is returned when a payload has a different version than expected.
+	ErrVersionMismatch = errors.New("payload version mismatch")
+	// ErrInvalidPayload is returned when the payload body cannot be decoded.
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// Bytes returns the 4-byte prefix of the version.
+func (v Version) Bytes() []byte {
+	bz := make([]byte, VersionLength)
+	binary.BigEndian.PutUint32(bz, uint32(v))
+	return bz
+}
+
+// String implements fmt.Stringer.
+func (v Version) String() string {
+	return fmt.Sprintf("0x%08x", uint32(v))
+}
+
+// Validate returns ErrUnsupportedVersion if v is not a known version.
+func (v Version) Validate() error {
+	switch v {
+	case VersionABI, VersionCosmWasmABI, VersionJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedVersion, v)
+	}
+}
+
+// Split returns the version and body of a payload.
+func Split(payload []byte) (Version, []byte, error) {
+	if len(payload) < VersionLength {
+		return 0, nil, fmt.Errorf("%w: %d bytes", ErrPayloadTooShort, len(payload))
+	}
+
+	version := Version(binary.BigEndian.Uint32(payload[:VersionLength]))
+	if err := version.Validate(); err != nil {
+		return 0, nil, err
+	}
+
+	return version, payload[VersionLength:], nil
+}
+
+// Join prefixes body with version.
+func Join(version Version, body []byte) []byte {
+	return append(version.Bytes(), body...)
+}
+
+// body returns the body of a payload that must have the expected version.
+func body(payload []byte, expected Version) ([]byte, error) {
+	version, bz, err := Split(payload)
+	if err != nil {
+		return nil, err
+	}
+	if version != expected {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrVersionMismatch, expected, version)
+	}
+	return bz, nil
+}
@@ -0,0 +1,71 @@
+package payload
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	version, body, err := Split(Join(VersionJSON, []byte("{}")))
+	require.NoError(t, err)
+	require.Equal(t, VersionJSON, version)
+	require.Equal(t, []byte("{}"), body)
+
+	_, _, err = Split([]byte{0x00, 0x00})
+	require.ErrorIs(t, err, ErrPayloadTooShort)
+
+	_, _, err = Split([]byte{0x00, 0x00, 0x00, 0x09})
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestABI(t *testing.T) {
+	args, err := Arguments("string", "uint256")
+	require.NoError(t, err)
+
+	bz, err := EncodeABI(args, "hello", big.NewInt(42))
+	require.NoError(t, err)
+	require.Equal(t, VersionABI.Bytes(), bz[:VersionLength])
+
+	values, err := DecodeABI(bz, args)
+	require.NoError(t, err)
+	require.Equal(t, "hello", values[0])
+	require.Equal(t, big.NewInt(42), values[1])
+
+	_, err = DecodeABI(Join(VersionJSON, bz[VersionLength:]), args)
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	_, err = DecodeABI(Join(VersionABI, []byte{0x01}), args)
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestJSON(t *testing.T) {
+	bz, err := EncodeJSON(map[string]string{"key": "value"})
+	require.NoError(t, err)
+
+	var decoded map[string]string
+	require.NoError(t, DecodeJSON(bz, &decoded))
+	require.Equal(t, map[string]string{"key": "value"}, decoded)
+
+	require.ErrorIs(t, DecodeJSON(Join(VersionJSON, []byte("{")), &decoded), ErrInvalidPayload)
+}
+
+func TestCosmWasmABI(t *testing.T) {
+	call, err := NewCosmWasmCall("transfer", []string{"recipient", "memo"}, []string{"string", "string"}, "cosmos1recipient", "hi")
+	require.NoError(t, err)
+
+	bz, err := EncodeCosmWasmABI(call)
+	require.NoError(t, err)
+
+	decoded, err := DecodeCosmWasmABI(bz)
+	require.NoError(t, err)
+	require.Equal(t, call, decoded)
+
+	args, err := decoded.Args()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"cosmos1recipient", "hi"}, args)
+
+	_, err = NewCosmWasmCall("transfer", []string{"recipient"}, []string{"string", "string"}, "a", "b")
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
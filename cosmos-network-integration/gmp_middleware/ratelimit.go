@@ -0,0 +1,230 @@
+// GMP rate limits: governance-managed quotas on the inbound GMP messages from a source chain
+// and address to a local destination, counting messages and the delivered amount of a denom
+// per time window. They complement the Stride ratelimit module further up the transfer stack,
+// which only sees denoms and channels. The denom of a rate limit may also be a registered Axelar
+// asset symbol. A packet over quota gets an ErrRateLimited error ack, which also reverts the
+// flow recorded by the ratelimit module for it. Express executions are charged when they run,
+// with the expressed coin, and the packet that settles their claim is not charged again. Like
+// pauses, rate limits store and match source chains in lower case.
+
+package gmp_middleware
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Validate performs basic validation of a rate limit, whose source chain must be lower case.
+func (rl RateLimit) Validate() error {
+	if rl.SourceChain != normalizeChain(rl.SourceChain) {
+		return fmt.Errorf("rate limit source chain %q must be lower case", rl.SourceChain)
+	}
+	if rl.SourceAddress != "" && rl.SourceChain == "" {
+		return fmt.Errorf("rate limit on source address %s requires a source chain", rl.SourceAddress)
+	}
+	if rl.MaxMessages == 0 && rl.Denom == "" {
+		return fmt.Errorf("rate limit must cap messages, a token amount or both")
+	}
+	if rl.Denom != "" {
+		if err := sdk.ValidateDenom(rl.Denom); err != nil {
+			return err
+		}
+		if rl.MaxAmount.IsNil() || !rl.MaxAmount.IsPositive() {
+			return fmt.Errorf("rate limit max amount must be positive")
+		}
+	}
+	if rl.Window < 0 {
+		return fmt.Errorf("rate limit window cannot be negative")
+	}
+	return nil
+}
+
+// describe returns a description of the traffic covered by the rate limit.
+func (rl RateLimit) describe() string {
+	return fmt.Sprintf("source %s/%s to %s", wildcard(rl.SourceChain), wildcard(rl.SourceAddress), wildcard(rl.Destination))
+}
+
+func wildcard(s string) string {
+	if s == "" {
+		return "*"
+	}
+	return s
+}
+
+// ConsumeRateLimits records msg, delivering token to destination, against every rate limit
+// that covers it, and returns an ErrRateLimited error if that exceeds any of them.
+func (k Keeper) ConsumeRateLimits(ctx sdk.Context, msg Message, destination string, token sdk.Coin) error {
+	for _, rl := range k.matchingRateLimits(ctx, msg.SourceChain, msg.SourceAddress, destination) {
+		usage := k.currentUsage(ctx, rl)
+
+		usage.Messages++
+		if rl.MaxMessages > 0 && usage.Messages > rl.MaxMessages {
+			return errorsmod.Wrapf(ErrRateLimited, "%s exceeds %d messages", rl.describe(), rl.MaxMessages)
+		}
+
+		if rl.Denom != "" && k.ResolveDenom(ctx, rl.Denom) == token.Denom {
+			usage.Amount = usage.Amount.Add(token.Amount)
+			if usage.Amount.GT(rl.MaxAmount) {
+				return errorsmod.Wrapf(ErrRateLimited, "%s exceeds %s%s", rl.describe(), rl.MaxAmount, rl.Denom)
+			}
+		}
+
+		k.SetRateLimitUsage(ctx, usage)
+	}
+	return nil
+}
+
+// matchingRateLimits returns the rate limits covering messages from sourceChain/sourceAddress
+// to destination, from the most to the least specific.
+func (k Keeper) matchingRateLimits(ctx sdk.Context, sourceChain, sourceAddress, destination string) []RateLimit {
+	store := k.rateLimitStore(ctx)
+
+	var limits []RateLimit
+	for _, chain := range []string{normalizeChain(sourceChain), ""} {
+		addresses := []string{sourceAddress, ""}
+		if chain == "" {
+			addresses = []string{""}
+		}
+		for _, addr := range addresses {
+			for _, dest := range []string{destination, ""} {
+				bz := store.Get(rateLimitKey(chain, addr, dest))
+				if bz == nil {
+					continue
+				}
+				var rl RateLimit
+				k.cdc.MustUnmarshal(bz, &rl)
+				limits = append(limits, rl)
+			}
+		}
+	}
+	return limits
+}
+
+// currentUsage returns the usage of rl in the current window, starting a new window if the
+// stored one is over.
+func (k Keeper) currentUsage(ctx sdk.Context, rl RateLimit) RateLimitUsage {
+	usage, found := k.GetRateLimitUsage(ctx, rl.SourceChain, rl.SourceAddress, rl.Destination)
+
+	expired := !found
+	if found && rl.Window == 0 {
+		expired = usage.WindowStartHeight != ctx.BlockHeight()
+	} else if found {
+		expired = !ctx.BlockTime().Before(usage.WindowStartTime.Add(rl.Window))
+	}
+
+	if expired {
+		usage = RateLimitUsage{
+			SourceChain:       rl.SourceChain,
+			SourceAddress:     rl.SourceAddress,
+			Destination:       rl.Destination,
+			Amount:            sdkmath.ZeroInt(),
+			WindowStartHeight: ctx.BlockHeight(),
+			WindowStartTime:   ctx.BlockTime(),
+		}
+	}
+	return usage
+}
+
+// GetRateLimit returns the rate limit of sourceChain/sourceAddress to destination.
+func (k Keeper) GetRateLimit(ctx sdk.Context, sourceChain, sourceAddress, destination string) (RateLimit, bool) {
+	bz := k.rateLimitStore(ctx).Get(rateLimitKey(sourceChain, sourceAddress, destination))
+	if bz == nil {
+		return RateLimit{}, false
+	}
+
+	var rl RateLimit
+	k.cdc.MustUnmarshal(bz, &rl)
+	return rl, true
+}
+
+// SetRateLimit stores a rate limit, with its source chain in lower case, and resets its usage.
+func (k Keeper) SetRateLimit(ctx sdk.Context, rl RateLimit) {
+	rl.SourceChain = normalizeChain(rl.SourceChain)
+	key := rateLimitKey(rl.SourceChain, rl.SourceAddress, rl.Destination)
+	k.rateLimitStore(ctx).Set(key, k.cdc.MustMarshal(&rl))
+	k.rateLimitUsageStore(ctx).Delete(key)
+}
+
+// DeleteRateLimit removes a rate limit with its usage and reports whether it existed.
+func (k Keeper) DeleteRateLimit(ctx sdk.Context, sourceChain, sourceAddress, destination string) bool {
+	key := rateLimitKey(sourceChain, sourceAddress, destination)
+	store := k.rateLimitStore(ctx)
+	if !store.Has(key) {
+		return false
+	}
+	store.Delete(key)
+	k.rateLimitUsageStore(ctx).Delete(key)
+	return true
+}
+
+// GetAllRateLimits returns every rate limit.
+func (k Keeper) GetAllRateLimits(ctx sdk.Context) []RateLimit {
+	iterator := k.rateLimitStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var limits []RateLimit
+	for ; iterator.Valid(); iterator.Next() {
+		var rl RateLimit
+		k.cdc.MustUnmarshal(iterator.Value(), &rl)
+		limits = append(limits, rl)
+	}
+	return limits
+}
+
+// GetRateLimitUsage returns the stored usage of the rate limit of sourceChain/sourceAddress to destination.
+func (k Keeper) GetRateLimitUsage(ctx sdk.Context, sourceChain, sourceAddress, destination string) (RateLimitUsage, bool) {
+	bz := k.rateLimitUsageStore(ctx).Get(rateLimitKey(sourceChain, sourceAddress, destination))
+	if bz == nil {
+		return RateLimitUsage{}, false
+	}
+
+	var usage RateLimitUsage
+	k.cdc.MustUnmarshal(bz, &usage)
+	return usage, true
+}
+
+// SetRateLimitUsage stores the usage of a rate limit.
+func (k Keeper) SetRateLimitUsage(ctx sdk.Context, usage RateLimitUsage) {
+	key := rateLimitKey(usage.SourceChain, usage.SourceAddress, usage.Destination)
+	k.rateLimitUsageStore(ctx).Set(key, k.cdc.MustMarshal(&usage))
+}
+
+// GetAllRateLimitUsages returns the stored usage of every rate limit.
+func (k Keeper) GetAllRateLimitUsages(ctx sdk.Context) []RateLimitUsage {
+	iterator := k.rateLimitUsageStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var usages []RateLimitUsage
+	for ; iterator.Valid(); iterator.Next() {
+		var usage RateLimitUsage
+		k.cdc.MustUnmarshal(iterator.Value(), &usage)
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+func (k Keeper) rateLimitStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), RateLimitKeyPrefix)
+}
+
+func (k Keeper) rateLimitUsageStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), RateLimitUsageKeyPrefix)
+}
+
+// rateLimitKey returns the key of a rate limit and its usage:
+// <len><source_chain><len><source_address><destination>
+// The source chain is lower-cased, so rate limits match it regardless of case.
+func rateLimitKey(sourceChain, sourceAddress, destination string) []byte {
+	sourceChain = normalizeChain(sourceChain)
+	key := sdk.Uint64ToBigEndian(uint64(len(sourceChain)))
+	key = append(key, sourceChain...)
+	key = append(key, sdk.Uint64ToBigEndian(uint64(len(sourceAddress)))...)
+	key = append(key, sourceAddress...)
+	return append(key, destination...)
+}
@@ -0,0 +1,110 @@
+package gmp_middleware
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+func TestOnRecvPacketEnforcesMessageLimit(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+	f.keeper.SetRateLimit(f.ctx, RateLimit{SourceChain: testSourceChain, MaxMessages: 2, Window: time.Hour})
+
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-1")).Success())
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-2")).Success())
+	require.False(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-3")).Success())
+	require.Len(t, f.handler.calls, 2)
+
+	// a new window starts once the current one is over
+	f.ctx = f.ctx.WithBlockTime(f.ctx.BlockTime().Add(time.Hour))
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-4")).Success())
+	require.Len(t, f.handler.calls, 3)
+}
+
+func TestOnRecvPacketEnforcesAmountLimit(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+	f.keeper.SetRateLimit(f.ctx, RateLimit{Destination: receiver, Denom: testDenom(), MaxAmount: sdkmath.NewInt(150)})
+
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-5")).Success())
+	require.False(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-6")).Success())
+
+	// other destinations are not covered, and a zero window resets every block
+	require.True(t, f.recvPacket(t, testAddr("other").String(), 100, testMessageWithID("0xabc-7")).Success())
+	f.ctx = f.ctx.WithBlockHeight(f.ctx.BlockHeight() + 1)
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-8")).Success())
+}
+
+func TestConsumeRateLimitsChecksEveryMatchingLimit(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+	f.keeper.SetRateLimit(f.ctx, RateLimit{SourceChain: testSourceChain, SourceAddress: testSourceAddress, MaxMessages: 5})
+	f.keeper.SetRateLimit(f.ctx, RateLimit{MaxMessages: 1})
+
+	require.Len(t, f.keeper.matchingRateLimits(f.ctx, testSourceChain, testSourceAddress, receiver), 2)
+	require.NoError(t, f.keeper.ConsumeRateLimits(f.ctx, testMessage(), receiver, sdk.NewInt64Coin(testDenom(), 100)))
+	require.ErrorIs(t, f.keeper.ConsumeRateLimits(f.ctx, testMessage(), receiver, sdk.NewInt64Coin(testDenom(), 100)), ErrRateLimited)
+}
+
+func TestRateLimitsMatchSourceChainInAnyCase(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+	f.keeper.SetRateLimit(f.ctx, RateLimit{SourceChain: strings.ToUpper(testSourceChain), MaxMessages: 1, Window: time.Hour})
+	require.Equal(t, strings.ToLower(testSourceChain), f.keeper.GetAllRateLimits(f.ctx)[0].SourceChain)
+
+	msg := testMessage()
+	msg.SourceChain = strings.ToLower(testSourceChain)
+	require.True(t, f.recvPacket(t, receiver, 100, msg).Success())
+	require.False(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-2")).Success())
+	require.Len(t, f.handler.calls, 1)
+
+	require.True(t, f.keeper.DeleteRateLimit(f.ctx, testSourceChain, "", ""))
+	require.Empty(t, f.keeper.GetAllRateLimits(f.ctx))
+	require.Empty(t, f.keeper.GetAllRateLimitUsages(f.ctx))
+}
+
+func TestMsgSetRateLimit(t *testing.T) {
+	f := newTestFixture(t)
+	server := NewMsgServerImpl(f.keeper)
+	rl := RateLimit{SourceChain: testSourceChain, MaxMessages: 1}
+
+	_, err := server.SetRateLimit(f.ctx, &MsgSetRateLimit{Authority: testAddr("someone").String(), RateLimit: rl})
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+
+	_, err = server.SetRateLimit(f.ctx, &MsgSetRateLimit{Authority: testAuthority.String(), RateLimit: rl})
+	require.NoError(t, err)
+	stored, found := f.keeper.GetRateLimit(f.ctx, testSourceChain, "", "")
+	require.True(t, found)
+	require.Equal(t, rl.MaxMessages, stored.MaxMessages)
+	require.Equal(t, strings.ToLower(testSourceChain), stored.SourceChain)
+
+	_, err = server.RemoveRateLimit(f.ctx, &MsgRemoveRateLimit{Authority: testAuthority.String(), SourceChain: testSourceChain})
+	require.NoError(t, err)
+	_, err = server.RemoveRateLimit(f.ctx, &MsgRemoveRateLimit{Authority: testAuthority.String(), SourceChain: testSourceChain})
+	require.ErrorIs(t, err, ErrNoRateLimit)
+}
+
+func TestRateLimitValidate(t *testing.T) {
+	require.NoError(t, RateLimit{MaxMessages: 1}.Validate())
+	require.NoError(t, RateLimit{SourceChain: "ethereum", MaxMessages: 1}.Validate())
+
+	for name, rl := range map[string]RateLimit{
+		"no cap":                 {SourceChain: "ethereum"},
+		"source chain not lower": {SourceChain: "Ethereum", MaxMessages: 1},
+		"address without chain":  {SourceAddress: testSourceAddress, MaxMessages: 1},
+		"denom without amount":   {Denom: "stake"},
+		"invalid denom":          {Denom: "!", MaxAmount: sdkmath.OneInt()},
+		"negative window":        {MaxMessages: 1, Window: -time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, rl.Validate())
+		})
+	}
+}
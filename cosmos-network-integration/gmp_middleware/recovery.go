@@ -0,0 +1,108 @@
+// Recovery escrow: with the FAILURE_POLICY_RECOVERY_ESCROW policy the tokens of a failed
+// message are moved from the receiver into the gmp module account, and recorded against the
+// message's source address and the recovery address. Axelar memos name no recovery address,
+// so it is taken from the governance-set recovery_address param. That address can claim the
+// tokens later with MsgClaimRecovery, even if the param changed in the meantime.
+
+package gmp_middleware
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
+// EscrowForRecovery moves coin from receiver into the module's recovery escrow, claimable by the
+// recovery address param.
+func (k Keeper) EscrowForRecovery(ctx sdk.Context, msg Message, receiver string, coin sdk.Coin) error {
+	recoveryAddr, err := sdk.AccAddressFromBech32(k.GetParams(ctx).RecoveryAddress)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidMessage, "invalid recovery address: %s", err)
+	}
+
+	receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+	}
+
+	coins := sdk.NewCoins(coin)
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, receiverAddr, ModuleName, coins); err != nil {
+		return err
+	}
+
+	recovery, found := k.GetRecovery(ctx, recoveryAddr, msg.SourceChain, msg.SourceAddress)
+	if !found {
+		recovery = Recovery{
+			RecoveryAddress: recoveryAddr.String(),
+			SourceChain:     msg.SourceChain,
+			SourceAddress:   msg.SourceAddress,
+		}
+	}
+	recovery.Coins = recovery.Coins.Add(coins...)
+	k.SetRecovery(ctx, recovery)
+
+	return nil
+}
+
+// ClaimRecovery releases the recovery escrow of (sourceChain, sourceAddress) to claimer, which
+// must be the recovery address the failed messages were escrowed for.
+func (k Keeper) ClaimRecovery(ctx sdk.Context, claimer sdk.AccAddress, sourceChain, sourceAddress string) (sdk.Coins, error) {
+	recovery, found := k.GetRecovery(ctx, claimer, sourceChain, sourceAddress)
+	if !found {
+		return nil, errorsmod.Wrapf(ErrNoRecovery, "recovery address %s, source %s/%s", claimer, sourceChain, sourceAddress)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, claimer, recovery.Coins); err != nil {
+		return nil, err
+	}
+	k.recoveryStore(ctx).Delete(recoveryKey(claimer, sourceChain, sourceAddress))
+
+	return recovery.Coins, nil
+}
+
+// GetRecovery returns the recovery escrow of a source address for the given recovery address.
+func (k Keeper) GetRecovery(ctx sdk.Context, recoveryAddr sdk.AccAddress, sourceChain, sourceAddress string) (Recovery, bool) {
+	bz := k.recoveryStore(ctx).Get(recoveryKey(recoveryAddr, sourceChain, sourceAddress))
+	if bz == nil {
+		return Recovery{}, false
+	}
+
+	var recovery Recovery
+	k.cdc.MustUnmarshal(bz, &recovery)
+	return recovery, true
+}
+
+// SetRecovery stores a recovery escrow record.
+func (k Keeper) SetRecovery(ctx sdk.Context, recovery Recovery) {
+	recoveryAddr := sdk.MustAccAddressFromBech32(recovery.RecoveryAddress)
+	k.recoveryStore(ctx).Set(recoveryKey(recoveryAddr, recovery.SourceChain, recovery.SourceAddress), k.cdc.MustMarshal(&recovery))
+}
+
+// GetAllRecoveries returns every recovery escrow record.
+func (k Keeper) GetAllRecoveries(ctx sdk.Context) []Recovery {
+	iterator := k.recoveryStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var recoveries []Recovery
+	for ; iterator.Valid(); iterator.Next() {
+		var recovery Recovery
+		k.cdc.MustUnmarshal(iterator.Value(), &recovery)
+		recoveries = append(recoveries, recovery)
+	}
+	return recoveries
+}
+
+func (k Keeper) recoveryStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), RecoveryKeyPrefix)
+}
+
+// recoveryKey returns the key of a recovery escrow record:
+// <len><recovery_address><len><source_chain><source_address>
+func recoveryKey(recoveryAddr sdk.AccAddress, sourceChain, sourceAddress string) []byte {
+	key := address.MustLengthPrefix(recoveryAddr)
+	key = append(key, address.MustLengthPrefix([]byte(sourceChain))...)
+	return append(key, []byte(sourceAddress)...)
+}
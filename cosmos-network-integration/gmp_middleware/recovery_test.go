@@ -0,0 +1,78 @@
+package gmp_middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRecoveryEscrow(t *testing.T) {
+	f := newTestFixture(t)
+	recoveryAddr := testAddr("recovery")
+	f.setParams(t, func(p *Params) {
+		p.FailurePolicy = FailurePolicyRecoveryEscrow
+		p.RecoveryAddress = recoveryAddr.String()
+	})
+	f.handler.err = errors.New("handler failed")
+	receiver := testAddr("receiver")
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.True(t, f.bank.balance(receiver).IsZero())
+
+	escrowed := sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100))
+	require.Equal(t, escrowed, f.bank.balance(testModuleAddr))
+	require.Equal(t, []Recovery{{
+		RecoveryAddress: recoveryAddr.String(),
+		SourceChain:     testSourceChain,
+		SourceAddress:   testSourceAddress,
+		Coins:           escrowed,
+	}}, f.keeper.GetAllRecoveries(f.ctx))
+
+	_, err := f.keeper.ClaimRecovery(f.ctx, receiver, testSourceChain, testSourceAddress)
+	require.ErrorIs(t, err, ErrNoRecovery)
+
+	// the escrow stays claimable by the address it was kept for
+	f.setParams(t, func(p *Params) { p.RecoveryAddress = testAddr("new recovery").String() })
+	coins, err := f.keeper.ClaimRecovery(f.ctx, recoveryAddr, testSourceChain, testSourceAddress)
+	require.NoError(t, err)
+	require.Equal(t, escrowed, coins)
+	require.Equal(t, escrowed, f.bank.balance(recoveryAddr))
+	require.Empty(t, f.keeper.GetAllRecoveries(f.ctx))
+}
+
+func TestRecoveryEscrowWithoutToken(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) {
+		p.FailurePolicy = FailurePolicyRecoveryEscrow
+		p.RecoveryAddress = testAddr("recovery").String()
+	})
+	f.handler.err = errors.New("handler failed")
+	msg := testMessage()
+	msg.Type = TypeGeneralMessage
+
+	require.False(t, f.recvPacket(t, testAddr("receiver").String(), 100, msg).Success())
+	require.Empty(t, f.keeper.GetAllRecoveries(f.ctx))
+}
+
+func TestRecoveryEscrowRequiresRecoveryAddress(t *testing.T) {
+	params := DefaultParams()
+	params.FailurePolicy = FailurePolicyRecoveryEscrow
+	require.Error(t, params.Validate())
+
+	params.RecoveryAddress = "cosmos1invalid"
+	require.Error(t, params.Validate())
+
+	params.RecoveryAddress = testAddr("recovery").String()
+	require.NoError(t, params.Validate())
+}
+
+func TestDecodeMemoRejectsRecoveryAddress(t *testing.T) {
+	f := newTestFixture(t)
+
+	_, err := f.keeper.DecodeMemo(f.ctx, `{"source_chain":"Ethereum","source_address":"0x1","payload":"AAAACQ==","type":1,"recovery_address":"cosmos1xyz"}`)
+	require.ErrorIs(t, err, ErrInvalidMessage)
+}
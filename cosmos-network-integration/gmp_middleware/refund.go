@@ -0,0 +1,187 @@
+// Refunds to source: with the FAILURE_POLICY_REFUND_TO_SOURCE policy the tokens of a failed
+// message are moved from the receiver into the gmp module account and sent back through the
+// outbound Axelar channel as a TypeSendToken message to the message's source address. Stored
+// failed messages that expire are refunded the same way. Every refund is tracked under the
+// replay key of its message and indexed by its packet, so the acknowledgement can complete it.
+// The message is refunding until then, and refunded once Axelar acknowledged the refund.
+// The module account is blocked from sending transfers, so refunds are sent from the refund
+// account. A refund whose packet fails or times out is credited back to the refund account by
+// the transfer app, returned to module escrow and can be sent again with MsgRetryRefund.
+
+package gmp_middleware
+
+import (
+	"encoding/hex"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// RefundAddress returns the account that sends refunds to Axelar on behalf of the gmp module.
+func RefundAddress() sdk.AccAddress {
+	return address.Module(ModuleName, []byte("refund"))
+}
+
+// RefundFromReceiver moves coin from receiver into module escrow and refunds it to the source
+// address of the message with replay key id.
+func (k Keeper) RefundFromReceiver(ctx sdk.Context, id []byte, msg Message, receiver string, coin sdk.Coin) error {
+	receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidMessage, "invalid receiver: %s", err)
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, receiverAddr, ModuleName, sdk.NewCoins(coin)); err != nil {
+		return err
+	}
+
+	_, err = k.RefundToSource(ctx, Refund{
+		Id:            id,
+		SourceChain:   msg.SourceChain,
+		SourceAddress: msg.SourceAddress,
+		Coin:          coin,
+	})
+	return err
+}
+
+// RefundToSource sends the coin of refund, held in module escrow, to its source address through
+// Axelar, records the refund as pending and returns the sequence of the refund packet.
+func (k Keeper) RefundToSource(ctx sdk.Context, refund Refund) (uint64, error) {
+	refundAddr := RefundAddress()
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, refundAddr, sdk.NewCoins(refund.Coin)); err != nil {
+		return 0, errorsmod.Wrap(err, "cannot send refund")
+	}
+	sequence, err := k.SendToken(ctx, refundAddr, refund.SourceChain, refund.SourceAddress, refund.Coin)
+	if err != nil {
+		return 0, errorsmod.Wrap(err, "cannot send refund")
+	}
+
+	refund.ChannelId = k.GetParams(ctx).OutboundChannelId
+	refund.Sequence = sequence
+	refund.Status = RefundStatusPending
+	refund.Error = ""
+	refund.Height = ctx.BlockHeight()
+	k.SetRefund(ctx, refund)
+	k.setMessageStatus(ctx, refund.Id, MessageStatusRefunding, nil)
+
+	k.EmitEvent(ctx, &EventMessageRefunded{
+		Id:            hex.EncodeToString(refund.Id),
+		SourceChain:   refund.SourceChain,
+		SourceAddress: refund.SourceAddress,
+		Denom:         refund.Coin.Denom,
+		Amount:        refund.Coin.Amount.String(),
+		ChannelId:     refund.ChannelId,
+		Sequence:      refund.Sequence,
+	})
+	return sequence, nil
+}
+
+// RetryRefund sends the failed refund of the message with the given replay key again and
+// returns the sequence of the new refund packet.
+func (k Keeper) RetryRefund(ctx sdk.Context, id []byte) (uint64, error) {
+	refund, found := k.GetRefund(ctx, id)
+	if !found {
+		return 0, errorsmod.Wrapf(ErrNoRefund, "message %X", id)
+	}
+	if refund.Status != RefundStatusFailed {
+		return 0, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "refund of message %X is %s", id, refund.Status)
+	}
+
+	return k.RefundToSource(ctx, refund)
+}
+
+// onRefundPacket completes or fails the refund sent with packet, if any. ackErr is the error of
+// the acknowledgement or timeout, nil if the refund was acknowledged successfully. The tokens of
+// a failed refund, credited back to the refund account, return to module escrow.
+func (k Keeper) onRefundPacket(ctx sdk.Context, packet channeltypes.Packet, ackErr error) {
+	id := k.refundPacketIndexStore(ctx).Get(packetKey(packet.SourceChannel, packet.Sequence))
+	if id == nil {
+		return
+	}
+	refund, found := k.GetRefund(ctx, id)
+	if !found {
+		return
+	}
+
+	event := &EventRefundAcknowledged{
+		Id:        hex.EncodeToString(id),
+		ChannelId: packet.SourceChannel,
+		Sequence:  packet.Sequence,
+		Success:   ackErr == nil,
+	}
+	if ackErr != nil {
+		refund.Status = RefundStatusFailed
+		refund.Error = ackErr.Error()
+		event.Error = refund.Error
+
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, RefundAddress(), ModuleName, sdk.NewCoins(refund.Coin)); err != nil {
+			k.Logger(ctx).Error("cannot return failed gmp refund to module escrow",
+				"id", event.Id,
+				"error", err,
+			)
+		}
+
+		k.Logger(ctx).Error("gmp refund failed",
+			"id", event.Id,
+			"source_chain", refund.SourceChain,
+			"source_address", refund.SourceAddress,
+			"error", ackErr,
+		)
+	} else {
+		refund.Status = RefundStatusCompleted
+		k.setMessageStatus(ctx, id, MessageStatusRefunded, nil)
+	}
+
+	k.SetRefund(ctx, refund)
+	k.EmitEvent(ctx, event)
+}
+
+// GetRefund returns the refund of the message with the given replay key.
+func (k Keeper) GetRefund(ctx sdk.Context, id []byte) (Refund, bool) {
+	bz := k.refundStore(ctx).Get(id)
+	if bz == nil {
+		return Refund{}, false
+	}
+
+	var refund Refund
+	k.cdc.MustUnmarshal(bz, &refund)
+	return refund, true
+}
+
+// SetRefund stores a refund and indexes it by its packet while it is pending.
+func (k Keeper) SetRefund(ctx sdk.Context, refund Refund) {
+	k.refundStore(ctx).Set(refund.Id, k.cdc.MustMarshal(&refund))
+
+	key := packetKey(refund.ChannelId, refund.Sequence)
+	if refund.Status == RefundStatusPending {
+		k.refundPacketIndexStore(ctx).Set(key, refund.Id)
+	} else {
+		k.refundPacketIndexStore(ctx).Delete(key)
+	}
+}
+
+// GetAllRefunds returns every refund.
+func (k Keeper) GetAllRefunds(ctx sdk.Context) []Refund {
+	iterator := k.refundStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var refunds []Refund
+	for ; iterator.Valid(); iterator.Next() {
+		var refund Refund
+		k.cdc.MustUnmarshal(iterator.Value(), &refund)
+		refunds = append(refunds, refund)
+	}
+	return refunds
+}
+
+func (k Keeper) refundStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), RefundKeyPrefix)
+}
+
+func (k Keeper) refundPacketIndexStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), RefundPacketIndexKeyPrefix)
+}
@@ -0,0 +1,127 @@
+package gmp_middleware
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// refundPacket returns the packet of the last transfer sent by the transfer keeper.
+func (f *testFixture) refundPacket() channeltypes.Packet {
+	transfer := f.transfer.transfers[len(f.transfer.transfers)-1]
+	data := transfertypes.NewFungibleTokenPacketData(transfer.Token.Denom, transfer.Token.Amount.String(), transfer.Sender, transfer.Receiver, transfer.Memo)
+	return channeltypes.NewPacket(data.GetBytes(), uint64(len(f.transfer.transfers)), transfertypes.PortID, testChannel,
+		transfertypes.PortID, "channel-7", transfer.TimeoutHeight, transfer.TimeoutTimestamp)
+}
+
+func TestRefundToSourceSendsFromRefundAccount(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyRefundToSource })
+	f.handler.err = errors.New("boom")
+	receiver := testAddr("receiver")
+
+	ack := f.recvPacket(t, receiver.String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Empty(t, f.bank.balance(receiver))
+
+	require.Len(t, f.transfer.transfers, 1)
+	transfer := f.transfer.transfers[0]
+	require.Equal(t, RefundAddress().String(), transfer.Sender)
+	require.Equal(t, sdk.NewInt64Coin(testDenom(), 100), transfer.Token)
+	require.True(t, f.bank.balance(testModuleAddr).IsZero())
+	require.True(t, f.bank.balance(RefundAddress()).IsZero())
+}
+
+func TestFailedRefundReturnsToModuleEscrow(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyRefundToSource })
+	f.handler.err = errors.New("boom")
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	refunds := f.keeper.GetAllRefunds(f.ctx)
+	require.Len(t, refunds, 1)
+	id := refunds[0].Id
+
+	// the transfer app credits the timed out refund back to its sender
+	packet := f.refundPacket()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100))
+	escrow := transfertypes.GetEscrowAddress(transfertypes.PortID, testChannel)
+	require.NoError(t, f.bank.SendCoins(f.ctx, escrow, RefundAddress(), coins))
+	f.keeper.OnTimeoutPacket(f.ctx, packet)
+
+	refund, found := f.keeper.GetRefund(f.ctx, id)
+	require.True(t, found)
+	require.Equal(t, RefundStatusFailed, refund.Status)
+	require.Equal(t, coins, f.bank.balance(testModuleAddr))
+	require.True(t, f.bank.balance(RefundAddress()).IsZero())
+
+	_, err := f.keeper.RetryRefund(f.ctx, id)
+	require.NoError(t, err)
+	require.Len(t, f.transfer.transfers, 2)
+	require.Equal(t, RefundAddress().String(), f.transfer.transfers[1].Sender)
+	require.True(t, f.bank.balance(testModuleAddr).IsZero())
+}
+
+func TestRefundToSourceCompletesOnAck(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyRefundToSource })
+	f.handler.err = errors.New("boom")
+	querier := NewQuerier(f.keeper)
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, testMessage())
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	record, found := f.keeper.GetMessageRecordByPacket(f.ctx, testChannel, f.sequence)
+	require.True(t, found)
+	require.Equal(t, MessageStatusRefunding, record.Status)
+
+	var memo OutboundMessage
+	require.NoError(t, json.Unmarshal([]byte(f.transfer.transfers[0].Memo), &memo))
+	require.Equal(t, testSourceChain, memo.DestinationChain)
+	require.Equal(t, testSourceAddress, memo.DestinationAddress)
+	require.Equal(t, int64(TypeSendToken), memo.Type)
+
+	pending, err := querier.Refunds(f.ctx, &QueryRefundsRequest{Status: RefundStatusPending})
+	require.NoError(t, err)
+	require.Len(t, pending.Refunds, 1)
+
+	// only failed refunds can be sent again
+	_, err = f.keeper.RetryRefund(f.ctx, record.Id)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	f.keeper.OnAcknowledgementPacket(f.ctx, f.refundPacket(), channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement())
+	record, _ = f.keeper.GetMessageRecord(f.ctx, record.Id)
+	require.Equal(t, MessageStatusRefunded, record.Status)
+
+	res, err := querier.Refund(f.ctx, &QueryRefundRequest{Id: hex.EncodeToString(record.Id)})
+	require.NoError(t, err)
+	require.Equal(t, RefundStatusCompleted, res.Refund.Status)
+	require.True(t, hasEvent(f.ctx, "gmp.v1.EventRefundAcknowledged"))
+}
+
+func TestRefundToSourceRequiresTokens(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) { p.FailurePolicy = FailurePolicyRefundToSource })
+	f.handler.err = errors.New("boom")
+
+	msg := testMessage()
+	msg.Type = TypeGeneralMessage
+	require.False(t, f.recvPacket(t, testAddr("receiver").String(), 100, msg).Success())
+	require.Empty(t, f.transfer.transfers)
+	require.Empty(t, f.keeper.GetAllRefunds(f.ctx))
+}
+
+func TestRetryRefundUnknownMessage(t *testing.T) {
+	f := newTestFixture(t)
+
+	_, err := f.keeper.RetryRefund(f.ctx, []byte("unknown"))
+	require.ErrorIs(t, err, ErrNoRefund)
+}
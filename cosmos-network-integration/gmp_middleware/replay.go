@@ -0,0 +1,123 @@
+// Replay protection: every executed inbound message is remembered under a key derived from its
+// source chain, source address, payload hash and a nonce. General messages must carry a
+// message_id, which is their nonce, so a memo replayed in a new transfer maps to the same key
+// and is rejected; memos without one are rejected when decoded. Send token messages run no
+// handler and only move the tokens of their own packet, so their nonce is the identity of the
+// delivering packet. Records expire after the governance-set retention and are pruned at the
+// end of each block, together with the history record of the message.
+
+package gmp_middleware
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// MessageID returns the replay key of an inbound message. General messages are keyed on their
+// message ID, which memo decoding requires. Send token messages without one are keyed on the
+// packet's destination port, channel and sequence.
+func MessageID(packet channeltypes.Packet, msg Message) []byte {
+	nonce := msg.MessageID
+	if nonce == "" && msg.Type == TypeSendToken {
+		nonce = fmt.Sprintf("%s/%s/%d", packet.GetDestPort(), packet.GetDestChannel(), packet.GetSequence())
+	}
+	return messageKey(msg, nonce)
+}
+
+// messageKey hashes the source, payload and nonce of a message into its replay key.
+func messageKey(msg Message, nonce string) []byte {
+	payloadHash := sha256.Sum256(msg.Payload)
+	return hashParts([]byte(msg.SourceChain), []byte(msg.SourceAddress), payloadHash[:], []byte(nonce))
+}
+
+// hashParts hashes the length-prefixed parts into a key.
+func hashParts(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(sdk.Uint64ToBigEndian(uint64(len(part))))
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// HasProcessedMessage reports whether the message with the given replay key was already executed.
+func (k Keeper) HasProcessedMessage(ctx sdk.Context, id []byte) bool {
+	return k.processedMessageStore(ctx).Has(id)
+}
+
+// SetProcessedMessage records the message with the given replay key as executed.
+func (k Keeper) SetProcessedMessage(ctx sdk.Context, id []byte) {
+	expiry := uint64(ctx.BlockHeight()) + k.GetParams(ctx).ReplayRetentionBlocks
+	k.setProcessedMessage(ctx, ProcessedMessage{Id: id, ExpiryHeight: expiry})
+}
+
+func (k Keeper) setProcessedMessage(ctx sdk.Context, pm ProcessedMessage) {
+	k.processedMessageStore(ctx).Set(pm.Id, sdk.Uint64ToBigEndian(pm.ExpiryHeight))
+	if pm.ExpiryHeight > 0 {
+		k.processedExpiryStore(ctx).Set(processedExpiryKey(pm.ExpiryHeight, pm.Id), []byte{})
+	}
+}
+
+// GetAllProcessedMessages returns every processed message record.
+func (k Keeper) GetAllProcessedMessages(ctx sdk.Context) []ProcessedMessage {
+	iterator := k.processedMessageStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var records []ProcessedMessage
+	for ; iterator.Valid(); iterator.Next() {
+		records = append(records, ProcessedMessage{
+			Id:           iterator.Key(),
+			ExpiryHeight: sdk.BigEndianToUint64(iterator.Value()),
+		})
+	}
+	return records
+}
+
+// PruneProcessedMessages deletes processed message records that expired at or before the
+// current height, up to the configured per-block limit.
+func (k Keeper) PruneProcessedMessages(ctx sdk.Context) {
+	limit := k.GetParams(ctx).ReplayPruneLimit
+	end := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()) + 1)
+
+	expiryStore := k.processedExpiryStore(ctx)
+	iterator := expiryStore.Iterator(nil, end)
+
+	var expired [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		if uint64(len(expired)) >= limit {
+			break
+		}
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+
+	messageStore := k.processedMessageStore(ctx)
+	for _, key := range expired {
+		expiryStore.Delete(key)
+		messageStore.Delete(key[8:])
+		k.deleteMessageRecord(ctx, key[8:])
+	}
+
+	if len(expired) > 0 {
+		k.Logger(ctx).Debug("pruned processed gmp messages", "count", len(expired))
+	}
+}
+
+func (k Keeper) processedMessageStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), ProcessedMessageKeyPrefix)
+}
+
+func (k Keeper) processedExpiryStore(ctx sdk.Context) prefix.Store {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), ProcessedExpiryKeyPrefix)
+}
+
+// processedExpiryKey returns the key of the expiry index: <expiry_height><id>
+func processedExpiryKey(height uint64, id []byte) []byte {
+	return append(sdk.Uint64ToBigEndian(height), id...)
+}
@@ -0,0 +1,64 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnRecvPacketRejectsReplayedMessageID(t *testing.T) {
+	f := newTestFixture(t)
+	receiver := testAddr("receiver").String()
+
+	// the same memo in a new transfer is a replay
+	require.True(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	require.False(t, f.recvPacket(t, receiver, 100, testMessage()).Success())
+	require.False(t, f.recvPacket(t, testAddr("other").String(), 50, testMessage()).Success())
+	require.Len(t, f.handler.calls, 1)
+
+	require.True(t, f.recvPacket(t, receiver, 100, testMessageWithID("0xabc-2")).Success())
+	require.Len(t, f.handler.calls, 2)
+	require.Len(t, f.keeper.GetAllProcessedMessages(f.ctx), 2)
+}
+
+func TestOnRecvPacketRequiresMessageID(t *testing.T) {
+	f := newTestFixture(t)
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, testMessageWithID(""))
+	require.False(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+	require.Empty(t, f.keeper.GetAllProcessedMessages(f.ctx))
+}
+
+func TestOnRecvPacketKeysSendTokenOnPacket(t *testing.T) {
+	f := newTestFixture(t)
+	recipient := testAddr("recipient")
+
+	// send token messages only move the tokens of their own packet and need no message ID
+	require.True(t, f.recvPacket(t, DeliveryAddress().String(), 100, testSendToken(recipient)).Success())
+	require.True(t, f.recvPacket(t, DeliveryAddress().String(), 100, testSendToken(recipient)).Success())
+	require.Len(t, f.keeper.GetAllProcessedMessages(f.ctx), 2)
+}
+
+func TestPruneProcessedMessages(t *testing.T) {
+	f := newTestFixture(t)
+	f.setParams(t, func(p *Params) {
+		p.ReplayRetentionBlocks = 10
+		p.ReplayPruneLimit = 1
+	})
+
+	first, second := messageKey(testMessage(), "1"), messageKey(testMessage(), "2")
+	f.keeper.SetProcessedMessage(f.ctx, first)
+	f.keeper.SetProcessedMessage(f.ctx, second)
+
+	f.ctx = f.ctx.WithBlockHeight(10)
+	f.keeper.PruneProcessedMessages(f.ctx)
+	require.Len(t, f.keeper.GetAllProcessedMessages(f.ctx), 2)
+
+	f.ctx = f.ctx.WithBlockHeight(11)
+	f.keeper.PruneProcessedMessages(f.ctx)
+	require.Len(t, f.keeper.GetAllProcessedMessages(f.ctx), 1)
+	f.keeper.PruneProcessedMessages(f.ctx)
+	require.False(t, f.keeper.HasProcessedMessage(f.ctx, first))
+	require.False(t, f.keeper.HasProcessedMessage(f.ctx, second))
+}
@@ -0,0 +1,95 @@
+// The Router lets several applications on the same chain receive GMP messages. Each
+// GeneralMessageHandler is registered under a destination address (module account or
+// contract), a payload version prefix, or both. When a message arrives the most specific
+// route wins:
+//
+//	(destination, version) -> (destination, any version) -> (any destination, version)
+
+package gmp_middleware
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+)
+
+// PayloadVersionLength is the length of the version prefix at the start of a GMP payload.
+const PayloadVersionLength = 4
+
+type routeKey struct {
+	destination string
+	version     string
+}
+
+// Router dispatches inbound GMP messages to registered GeneralMessageHandlers.
+type Router struct {
+	routes map[routeKey]GeneralMessageHandler
+	sealed bool
+}
+
+// NewRouter returns an empty, unsealed Router.
+func NewRouter() *Router {
+	return &Router{
+		routes: make(map[routeKey]GeneralMessageHandler),
+	}
+}
+
+// AddRoute registers a handler for messages sent to destination whose payload starts with
+// version. An empty destination or a nil version matches any value, but not both at once.
+// It panics if the router is sealed or the route is already taken.
+func (r *Router) AddRoute(destination string, version []byte, handler GeneralMessageHandler) *Router {
+	if r.sealed {
+		panic("cannot add route to a sealed gmp router")
+	}
+	if handler == nil {
+		panic("gmp route handler cannot be nil")
+	}
+	if destination == "" && version == nil {
+		panic("gmp route requires a destination, a payload version or both")
+	}
+	if version != nil && len(version) != PayloadVersionLength {
+		panic(fmt.Sprintf("gmp route payload version must be %d bytes, got %d", PayloadVersionLength, len(version)))
+	}
+
+	key := routeKey{destination: destination, version: string(version)}
+	if _, ok := r.routes[key]; ok {
+		panic(fmt.Sprintf("gmp route already registered for destination %q and version %x", destination, version))
+	}
+
+	r.routes[key] = handler
+	return r
+}
+
+// Seal prevents any further routes from being added.
+func (r *Router) Seal() {
+	r.sealed = true
+}
+
+// Sealed reports whether the router is sealed.
+func (r *Router) Sealed() bool {
+	return r.sealed
+}
+
+// Route returns the handler for a message sent to destination with the given payload.
+func (r *Router) Route(destination string, payload []byte) (GeneralMessageHandler, error) {
+	version := ""
+	if len(payload) >= PayloadVersionLength {
+		version = string(payload[:PayloadVersionLength])
+	}
+
+	candidates := []routeKey{
+		{destination: destination, version: version},
+		{destination: destination},
+		{version: version},
+	}
+	for _, key := range candidates {
+		if key.destination == "" && key.version == "" {
+			continue
+		}
+		if handler, ok := r.routes[key]; ok {
+			return handler, nil
+		}
+	}
+
+	return nil, errorsmod.Wrapf(ErrNoHandler, "destination %s, payload version %x", destination, []byte(version))
+}
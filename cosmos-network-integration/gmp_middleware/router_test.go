@@ -0,0 +1,54 @@
+package gmp_middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterRoute(t *testing.T) {
+	versioned, destination, specific := &mockHandler{}, &mockHandler{}, &mockHandler{}
+	dest := testAddr("contract").String()
+
+	router := NewRouter().
+		AddRoute("", testPayloadVersion, versioned).
+		AddRoute(dest, nil, destination).
+		AddRoute(dest, testPayloadVersion, specific)
+	payload := testMessage().Payload
+
+	handler, err := router.Route(dest, payload)
+	require.NoError(t, err)
+	require.Same(t, specific, handler)
+
+	handler, err = router.Route(dest, []byte{0xff, 0xff, 0xff, 0xff})
+	require.NoError(t, err)
+	require.Same(t, destination, handler)
+
+	handler, err = router.Route(testAddr("other").String(), payload)
+	require.NoError(t, err)
+	require.Same(t, versioned, handler)
+
+	_, err = router.Route(testAddr("other").String(), []byte{0xff})
+	require.ErrorIs(t, err, ErrNoHandler)
+}
+
+func TestRouterAddRoute(t *testing.T) {
+	router := NewRouter().AddRoute("", testPayloadVersion, &mockHandler{})
+
+	require.Panics(t, func() { router.AddRoute("", testPayloadVersion, &mockHandler{}) })
+	require.Panics(t, func() { router.AddRoute("", nil, &mockHandler{}) })
+	require.Panics(t, func() { router.AddRoute("", []byte{0x01}, &mockHandler{}) })
+
+	router.Seal()
+	require.Panics(t, func() { router.AddRoute(testAddr("contract").String(), nil, &mockHandler{}) })
+}
+
+func TestOnRecvPacketWithoutRoute(t *testing.T) {
+	f := newTestFixture(t)
+	msg := testMessage()
+	msg.Payload = []byte{0xff, 0xff, 0xff, 0xff}
+
+	ack := f.recvPacket(t, testAddr("receiver").String(), 100, msg)
+	require.False(t, ack.Success())
+	require.Empty(t, f.handler.calls)
+}
@@ -9,8 +9,6 @@ package gmp_middleware
 import (
 	"fmt"
 
-	"log"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
@@ -23,15 +21,41 @@ type GeneralMessageHandler interface {
 	HandleGeneralMessageWithToken(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte, coin sdk.Coin) error
 }
 
-// TODO: Replace this placeholder with the actual Axelar GMP account address.
-const AxelarGMPAcc = ""
-
 // Message represents a general message attached in the ICS20 packet memo field.
 type Message struct {
 	SourceChain   string `json:"source_chain"`
 	SourceAddress string `json:"source_address"`
 	Payload       []byte `json:"payload"`
 	Type          int64  `json:"type"`
+	// MessageID is the ID or nonce the sender assigned to the message. It is required for
+	// general messages, whose replay protection is keyed on it, see MessageID.
+	MessageID string `json:"message_id,omitempty"`
+	// Fee is optionally paid out of the transferred token to a local recipient before the
+	// handler runs.
+	Fee *Fee `json:"fee,omitempty"`
+	// DestinationAddress is the local recipient of a TypeSendToken message. It is only needed
+	// when the packet is addressed to the gmp delivery account, see DeliveryAddress.
+	DestinationAddress string `json:"destination_address,omitempty"`
+	// Next optionally passes the delivered tokens on to a forward or a contract once the
+	// handler succeeded, less what the handler spent. The packet must then be addressed to the
+	// gmp delivery account.
+	Next *NextHop `json:"next,omitempty"`
+}
+
+// OutboundMessage represents a general message attached in the memo of ICS20 packets sent to Axelar.
+type OutboundMessage struct {
+	DestinationChain   string `json:"destination_chain"`
+	DestinationAddress string `json:"destination_address"`
+	Payload            []byte `json:"payload"`
+	Type               int64  `json:"type"`
+	Fee                *Fee   `json:"fee,omitempty"`
+}
+
+// Fee is the part of the transferred token paid to the recipient, e.g. the Axelar gas receiver
+// for outbound messages, or an executor or relayer for inbound ones.
+type Fee struct {
+	Amount    string `json:"amount"`
+	Recipient string `json:"recipient"`
 }
 
 // MessageType enumerates the types of messages handled by this middleware.
@@ -41,21 +65,18 @@ const (
 	TypeUnrecognized            = iota // Unrecognized message type
 	TypeGeneralMessage                 // General message
 	TypeGeneralMessageWithToken        // General message that includes token information
+	TypeSendToken                      // Direct token transfer
 )
 
 // parseDenom converts the denomination to the receiver chain representation.
 // It handles whether the receiver chain is the source of the token and removes or adds appropriate prefixes.
 func parseDenom(packet channeltypes.Packet, denom string) string {
-	log.Printf("Parsing denomination: %s for packet sequence: %d", denom, packet.Sequence)
-
 	// If the receiver chain is the source of the token
 	if types.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), denom) {
-		log.Println("Receiver chain is the source of the token")
-
 		// Remove the prefix added by the sender chain
 		voucherPrefix := types.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
 		if len(denom) <= len(voucherPrefix) {
-			log.Printf("Invalid denomination: %s. Cannot remove prefix as it is shorter than the prefix length.", denom)
+			// Cannot remove a prefix longer than the denomination itself
 			return denom
 		}
 		unprefixedDenom := denom[len(voucherPrefix):]
@@ -67,49 +88,54 @@ func parseDenom(packet channeltypes.Packet, denom string) string {
 		denomTrace := types.ParseDenomTrace(unprefixedDenom)
 		if denomTrace.Path != "" {
 			denom = denomTrace.IBCDenom()
-			log.Printf("Parsed non-native denomination to IBC format: %s", denom)
 		}
 
 		return denom
 	}
 
-	// If the receiver chain is NOT the source of the token
-	log.Println("Receiver chain is NOT the source of the token")
-
+	// If the receiver chain is NOT the source of the token, add the prefix of the destination chain
 	prefixedDenom := transfertypes.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel()) + denom
-	denom = transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
-
-	log.Printf("Parsed denomination with prefix added by destination chain: %s", denom)
-	return denom
+	return transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
 }
 
 // validateMessage ensures that the Message struct is properly formed.
 func validateMessage(msg *Message) error {
 	if msg == nil {
-		log.Println("Received a nil message for validation")
 		return fmt.Errorf("message cannot be nil")
 	}
 
 	if msg.SourceChain == "" {
-		log.Println("Validation failed: SourceChain is empty")
 		return fmt.Errorf("source_chain field is required")
 	}
 
 	if msg.SourceAddress == "" {
-		log.Println("Validation failed: SourceAddress is empty")
 		return fmt.Errorf("source_address field is required")
 	}
 
-	if len(msg.Payload) == 0 {
-		log.Println("Validation failed: Payload is empty")
-		return fmt.Errorf("payload field is required")
+	switch msg.Type {
+	case TypeGeneralMessage, TypeGeneralMessageWithToken:
+		if len(msg.Payload) == 0 {
+			return fmt.Errorf("payload field is required")
+		}
+		if msg.MessageID == "" {
+			return fmt.Errorf("message_id field is required")
+		}
+	case TypeSendToken:
+	default:
+		return fmt.Errorf("unrecognized message type: %d", msg.Type)
 	}
 
-	if msg.Type != TypeGeneralMessage && msg.Type != TypeGeneralMessageWithToken {
-		log.Printf("Validation failed: Unrecognized message type %d", msg.Type)
-		return fmt.Errorf("unrecognized message type: %d", msg.Type)
+	if msg.Next != nil {
+		if msg.Type == TypeGeneralMessage {
+			return fmt.Errorf("next hop requires a message with token")
+		}
+		if msg.DestinationAddress != "" {
+			return fmt.Errorf("next hop cannot be combined with destination_address")
+		}
+		if err := msg.Next.Validate(); err != nil {
+			return err
+		}
 	}
 
-	log.Println("Message validation successful")
 	return nil
 }
@@ -0,0 +1,105 @@
+// CosmWasm execution: the WasmHandler executes version 1 payloads, CosmWasm calls described
+// with ABI as built by SendReceive._encodePayloadToCosmWasm on EVM, without going through
+// ibc-hooks. The call is translated into its JSON execute message on-chain and the destination
+// contract is executed from the account derived from the message source, see SenderAddress,
+// with the delivered tokens attached as funds. Since the argument values are chosen by the
+// sender, source_chain and source_address arguments must name the actual source of the message.
+
+package gmp_middleware
+
+import (
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
+)
+
+var _ GeneralMessageHandler = WasmHandler{}
+
+// WasmHandler executes CosmWasm contracts for version 1 payloads.
+type WasmHandler struct {
+	contractKeeper ContractKeeper
+	bankKeeper     BankKeeper
+}
+
+// NewWasmHandler creates a new WasmHandler. Register it for payload.VersionCosmWasmABI.
+func NewWasmHandler(contractKeeper ContractKeeper, bankKeeper BankKeeper) WasmHandler {
+	return WasmHandler{
+		contractKeeper: contractKeeper,
+		bankKeeper:     bankKeeper,
+	}
+}
+
+// HandleGeneralMessage executes the destination contract without funds.
+func (h WasmHandler) HandleGeneralMessage(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte) error {
+	return h.execute(ctx, srcChain, srcAddress, destAddress, payload, nil)
+}
+
+// HandleGeneralMessageWithToken executes the destination contract with coin, which the
+// transfer app already credited to the contract, attached as funds.
+func (h WasmHandler) HandleGeneralMessageWithToken(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte, coin sdk.Coin) error {
+	return h.execute(ctx, srcChain, srcAddress, destAddress, payload, sdk.NewCoins(coin))
+}
+
+func (h WasmHandler) execute(ctx sdk.Context, srcChain, srcAddress, destAddress string, bz []byte, funds sdk.Coins) error {
+	contract, err := sdk.AccAddressFromBech32(destAddress)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidMessage, "invalid contract address: %s", err)
+	}
+
+	call, err := payload.DecodeCosmWasmABI(bz)
+	if err != nil {
+		return errorsmod.Wrap(ErrInvalidMessage, err.Error())
+	}
+	if err := checkSourceArgs(call, srcChain, srcAddress); err != nil {
+		return err
+	}
+	msg, err := call.ExecuteMsg()
+	if err != nil {
+		return errorsmod.Wrap(ErrInvalidMessage, err.Error())
+	}
+
+	// The funds already sit with the contract, so hand them to the caller first for the
+	// execution to attach them again.
+	sender := SenderAddress(srcChain, srcAddress)
+	if !funds.IsZero() {
+		if err := h.bankKeeper.SendCoins(ctx, contract, sender, funds); err != nil {
+			return err
+		}
+	}
+
+	if _, err := h.contractKeeper.Execute(ctx, contract, sender, msg, funds); err != nil {
+		return errorsmod.Wrapf(err, "cannot execute %s on %s", call.Method, destAddress)
+	}
+	return nil
+}
+
+// checkSourceArgs ensures that source_chain and source_address arguments of call, if any,
+// match the source of the message.
+func checkSourceArgs(call payload.CosmWasmCall, srcChain, srcAddress string) error {
+	values, err := call.Args()
+	if err != nil {
+		return errorsmod.Wrap(ErrInvalidMessage, err.Error())
+	}
+
+	for i, name := range call.ArgNames {
+		var expected string
+		switch name {
+		case "source_chain":
+			expected = srcChain
+		case "source_address":
+			expected = srcAddress
+		default:
+			continue
+		}
+
+		// EVM addresses may differ in their checksum casing.
+		if value, ok := values[i].(string); !ok || !strings.EqualFold(value, expected) {
+			return errorsmod.Wrapf(ErrInvalidMessage, "%s argument does not match the message source %s", name, expected)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package gmp_middleware
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
+)
+
+// testCosmWasmPayload returns a version 1 payload calling receive_message_evm with the given
+// source arguments and an amount.
+func testCosmWasmPayload(t *testing.T, sourceChain, sourceAddress string) []byte {
+	t.Helper()
+
+	call, err := payload.NewCosmWasmCall("receive_message_evm",
+		[]string{"source_chain", "source_address", "amount"},
+		[]string{"string", "string", "uint256"},
+		sourceChain, sourceAddress, big.NewInt(42))
+	require.NoError(t, err)
+	bz, err := payload.EncodeCosmWasmABI(call)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestWasmHandlerExecutesContract(t *testing.T) {
+	f := newTestFixture(t)
+	handler := NewWasmHandler(f.contracts, f.bank)
+	contract := testAddr("contract")
+
+	require.NoError(t, handler.HandleGeneralMessage(f.ctx, testSourceChain, testSourceAddress, contract.String(),
+		testCosmWasmPayload(t, testSourceChain, testSourceAddress)))
+	require.Equal(t, []string{
+		`{"receive_message_evm":{"amount":"42","source_address":"` + testSourceAddress + `","source_chain":"Ethereum"}}`,
+	}, f.contracts.calls)
+}
+
+func TestWasmHandlerAttachesFunds(t *testing.T) {
+	f := newTestFixture(t)
+	contract := testAddr("contract")
+
+	// the source address casing may differ from the payload argument
+	msg := testMessage()
+	msg.Payload = testCosmWasmPayload(t, testSourceChain, testSourceAddress)
+	msg.SourceAddress = "0x5425890298AED601595A70AB815C96711A31BC65"
+	ack := f.recvPacket(t, contract.String(), 100, msg)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+
+	require.Len(t, f.contracts.calls, 1)
+	require.Empty(t, f.handler.calls)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(testDenom(), 100)), f.bank.balance(contract))
+	require.True(t, f.bank.balance(SenderAddress(testSourceChain, msg.SourceAddress)).IsZero())
+}
+
+func TestWasmHandlerRejectsInvalidMessages(t *testing.T) {
+	contract := testAddr("contract").String()
+
+	for name, tc := range map[string]struct {
+		destination string
+		payload     func(t *testing.T) []byte
+	}{
+		"invalid contract": {
+			destination: "contract",
+			payload: func(t *testing.T) []byte {
+				return testCosmWasmPayload(t, testSourceChain, testSourceAddress)
+			},
+		},
+		"invalid payload": {
+			destination: contract,
+			payload: func(*testing.T) []byte {
+				return payload.Join(payload.VersionCosmWasmABI, []byte("not abi"))
+			},
+		},
+		"other source chain": {
+			destination: contract,
+			payload: func(t *testing.T) []byte {
+				return testCosmWasmPayload(t, "Avalanche", testSourceAddress)
+			},
+		},
+		"other source address": {
+			destination: contract,
+			payload: func(t *testing.T) []byte {
+				return testCosmWasmPayload(t, testSourceChain, "0x0000000000000000000000000000000000000001")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFixture(t)
+			handler := NewWasmHandler(f.contracts, f.bank)
+
+			err := handler.HandleGeneralMessage(f.ctx, testSourceChain, testSourceAddress, tc.destination, tc.payload(t))
+			require.ErrorIs(t, err, ErrInvalidMessage)
+			require.Empty(t, f.contracts.calls)
+		})
+	}
+}
+
+func TestWasmHandlerContractFailure(t *testing.T) {
+	f := newTestFixture(t)
+	f.contracts.err = errors.New("contract failed")
+	contract := testAddr("contract")
+
+	msg := testMessage()
+	msg.Payload = testCosmWasmPayload(t, testSourceChain, testSourceAddress)
+	ack := f.recvPacket(t, contract.String(), 100, msg)
+	require.False(t, ack.Success())
+	require.Empty(t, f.contracts.calls)
+	require.True(t, f.bank.balance(SenderAddress(testSourceChain, testSourceAddress)).IsZero())
+}
@@ -2,53 +2,33 @@ package keeper
 
 import (
 	"context"
-	"time"
 
+	"axelar-cosmos-go/cosmos-network-integration/gmp_middleware"
+	gmppayload "axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	transferkeeper "github.com/cosmos/ibc-go/v4/modules/apps/transfer/keeper"
-	ibctransfertypes "github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
-	clienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 )
 
-// AxelarGMPAcc is the address that receives the message from a cosmos chain
-const AxelarGMPAcc = ""
-
-type MessageType int
-
-const (
-	// TypeUnrecognized means coin type is unrecognized
-	TypeUnrecognized = iota
-	// TypeGeneralMessage is a pure message
-	TypeGeneralMessage
-	// TypeGeneralMessageWithToken is a general message with token
-	TypeGeneralMessageWithToken
-	// TypeSendToken is a direct token transfer
-	TypeSendToken
-)
-
-// Message is attached in ICS20 packet memo field
-type Message struct {
-	DestinationChain   string `json:"destination_chain"`
-	DestinationAddress string `json:"destination_address"`
-	Payload            []byte `json:"payload"`
-	Type               int64  `json:"type"`
+// GMPKeeper sends general messages through Axelar
+type GMPKeeper interface {
+	SendGeneralMessageWithToken(ctx sdk.Context, sender sdk.AccAddress, destinationChain, destinationAddress string, payload []byte, coin sdk.Coin, fee *gmp_middleware.Fee) (uint64, error)
 }
 
 type msgServer struct {
-	ibcTransferK transferkeeper.Keeper
+	gmpK GMPKeeper
 }
 
 // NewMsgServerImpl returns an implementation of the MsgServer interface
 // for the provided Keeper.
-func NewMsgServerImpl(ibcTransferK transferkeeper.Keeper) types.MsgServer {
+func NewMsgServerImpl(gmpK GMPKeeper) MsgServer {
 	return &msgServer{
-		ibcTransferK: ibcTransferK,
+		gmpK: gmpK,
 	}
 }
 
-func (k msgServer) Send(goCtx context.Context, msg *types.MsgSend) (*types.MsgSendResponse, error) {
+// Send sends msg.Amount with the receiver addresses to the destination contract. The amount
+// may name a registered Axelar asset by its symbol instead of its local denom.
+func (k msgServer) Send(goCtx context.Context, msg *MsgSend) (*MsgSendResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	sender, err := sdk.AccAddressFromBech32(msg.Sender)
@@ -56,8 +36,8 @@ func (k msgServer) Send(goCtx context.Context, msg *types.MsgSend) (*types.MsgSe
 		return nil, err
 	}
 
-	// build payload that can be decoded by solidity
-	addressesType, err := abi.NewType("address[]", "address[]", nil)
+	// build a version 0 payload, the same format the SendHandler decodes
+	addressesArgs, err := gmppayload.Arguments("address[]")
 	if err != nil {
 		return nil, err
 	}
@@ -67,38 +47,14 @@ func (k msgServer) Send(goCtx context.Context, msg *types.MsgSend) (*types.MsgSe
 		addresses = append(addresses, common.HexToAddress(receiver))
 	}
 
-	payload, err := abi.Arguments{{Type: addressesType}}.Pack(addresses)
+	payload, err := gmppayload.EncodeABI(addressesArgs, addresses)
 	if err != nil {
 		return nil, err
 	}
 
-	message := Message{
-		DestinationChain:   msg.DestinationChain,
-		DestinationAddress: msg.DestinationAddress,
-		Payload:            payload,
-		Type:               TypeGeneralMessageWithToken,
-	}
-
-	bz, err := message.Marshal()
-	if err != nil {
+	if _, err := k.gmpK.SendGeneralMessageWithToken(ctx, sender, msg.DestinationChain, msg.DestinationAddress, payload, msg.Amount, nil); err != nil {
 		return nil, err
 	}
 
-	msg := ibctransfertypes.NewMsgTransfer(
-		ibctransfertypes.PortID,
-		"", // TODO: Create channel_id
-		msg.Amount,
-		msg.Sender,
-		AxelarGMPAcc,
-		clienttypes.ZeroHeight(),
-		uint64(ctx.BlockTime().Add(6*time.Hour).UnixNano()),
-	)
-	msg.Memo = string(payload)
-
-	res, err := k.ibcTransferK.Transfer(goCtx, msg)
-	if err != nil {
-		return err
-	}
-
-	return &types.MsgSendResponse{}, nil
+	return &MsgSendResponse{}, nil
 }
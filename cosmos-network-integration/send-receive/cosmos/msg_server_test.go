@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"axelar-cosmos-go/cosmos-network-integration/gmp_middleware"
+	gmppayload "axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
+)
+
+// mockGMPKeeper records the payloads it is asked to send.
+type mockGMPKeeper struct {
+	payloads [][]byte
+}
+
+func (k *mockGMPKeeper) SendGeneralMessageWithToken(_ sdk.Context, _ sdk.AccAddress, _, _ string, payload []byte, _ sdk.Coin, _ *gmp_middleware.Fee) (uint64, error) {
+	k.payloads = append(k.payloads, payload)
+	return uint64(len(k.payloads)), nil
+}
+
+func TestSendEncodesVersionedPayload(t *testing.T) {
+	ctx := testutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("transient_test"))
+	gmpK := &mockGMPKeeper{}
+	receivers := []string{"0x5425890298aed601595a70AB815c96711a31Bc65", "0x0000000000000000000000000000000000000001"}
+
+	_, err := NewMsgServerImpl(gmpK).Send(ctx, &MsgSend{
+		Sender:             authtypes.NewModuleAddress("sender").String(),
+		DestinationChain:   "Ethereum",
+		DestinationAddress: "0x0000000000000000000000000000000000000002",
+		ReceiverAddresses:  receivers,
+		Amount:             sdk.NewInt64Coin("uaxl", 100),
+	})
+	require.NoError(t, err)
+	require.Len(t, gmpK.payloads, 1)
+
+	version, _, err := gmppayload.Split(gmpK.payloads[0])
+	require.NoError(t, err)
+	require.Equal(t, gmppayload.VersionABI, version)
+
+	args, err := gmppayload.Arguments("address[]")
+	require.NoError(t, err)
+	values, err := gmppayload.DecodeABI(gmpK.payloads[0], args)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{common.HexToAddress(receivers[0]), common.HexToAddress(receivers[1])}, values[0])
+}
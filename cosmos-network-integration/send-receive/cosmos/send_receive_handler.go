@@ -1,12 +1,17 @@
 package keeper
 
 import (
+	"context"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+
+	gmppayload "axelar-cosmos-go/cosmos-network-integration/gmp_middleware/payload"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
 type BankK interface {
-	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoins(ctx context.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
 }
 
 type SendHandler struct {
@@ -20,29 +25,38 @@ func NewSendHandler(k BankK) *SendHandler {
 
 }
 
-func (h SendHandler) HandleGeneralMessage(ctx sdk.Context, srcChain, srcAddress string, payload []byte) error {
+// AllowExpress lets express executors deliver tokens ahead of their packet: the handler only
+// splits the delivered coin between the receivers named in the payload.
+func (h SendHandler) AllowExpress(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte) bool {
+	return true
+}
+
+func (h SendHandler) HandleGeneralMessage(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte) error {
 	return nil
 }
 
-func (h SendHandler) HandleGeneralMessageWithToken(ctx sdk.Context, srcChain, srcAddress string, payload []byte, receiver string, coin sdk.Coin) error {
-	coinHolder, err := sdk.AccAddressFromBech32(receiver)
+func (h SendHandler) HandleGeneralMessageWithToken(ctx sdk.Context, srcChain, srcAddress string, destAddress string, payload []byte, coin sdk.Coin) error {
+	coinHolder, err := sdk.AccAddressFromBech32(destAddress)
 	if err != nil {
 		return err
 	}
 
-	// decode payload
-	addressesType, err := abi.NewType("string[]", "string[]", nil)
+	// decode payload: bytes4(0) followed by abi.encode(string[] receiverAddresses)
+	addressesArgs, err := gmppayload.Arguments("string[]")
 	if err != nil {
 		return err
 	}
 
-	args, err := abi.Arguments{{Type: addressesType}}.Unpack(payload)
+	args, err := gmppayload.DecodeABI(payload, addressesArgs)
 	if err != nil {
 		return err
 	}
 	addresses := args[0].([]string)
+	if len(addresses) == 0 {
+		return fmt.Errorf("payload has no receiver addresses")
+	}
 
-	amt := coin.Amount.Quo(sdk.NewInt(int64(len(addresses))))
+	amt := coin.Amount.Quo(sdkmath.NewInt(int64(len(addresses))))
 	c := sdk.NewCoin(coin.GetDenom(), amt)
 
 	for _, addr := range addresses {
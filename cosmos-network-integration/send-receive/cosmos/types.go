@@ -0,0 +1,25 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgSend sends Amount to the SendReceive contract at DestinationAddress on DestinationChain,
+// which splits it between ReceiverAddresses.
+type MsgSend struct {
+	Sender             string
+	DestinationChain   string
+	DestinationAddress string
+	ReceiverAddresses  []string
+	Amount             sdk.Coin
+}
+
+// MsgSendResponse is the response of MsgSend.
+type MsgSendResponse struct{}
+
+// MsgServer is the send-receive message service.
+type MsgServer interface {
+	Send(goCtx context.Context, msg *MsgSend) (*MsgSendResponse, error)
+}
@@ -52,7 +52,8 @@ contract SendReceive is AxelarExecutable {
         string calldata tokenSymbol,
         uint256 amount
         ) internal override {
-            address[] memory recipients = abi.decode(payload, (address[]));
+            // payload: bytes4 version 0 followed by abi.encode(address[] recipients)
+            address[] memory recipients = abi.decode(payload[4:], (address[]));
             address tokenAddress = gateway.tokenAddresses(tokenSymbol);
 
             uint256 sentAmount = amount / recipients.length;